@@ -12,6 +12,7 @@ import (
 	"github.com/dcos/dcos-cli/pkg/cli"
 	"github.com/dcos/dcos-cli/pkg/httpclient"
 	"github.com/dcos/dcos-core-cli/pkg/cmd"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 )
 
 const invalidCertError = "An SSL error occurred. To configure your SSL settings, " +
@@ -25,6 +26,9 @@ func main() {
 	ctx.Logger().SetLevel(pluginutil.Logger().Level)
 	if err := run(ctx); err != nil {
 		fmt.Fprintf(ctx.ErrOut(), "Error: %s\n", errorMessage(err))
+		if waitErr, ok := err.(*marathon.WaitError); ok {
+			os.Exit(waitErr.ExitCode())
+		}
 		os.Exit(1)
 	}
 }
@@ -16,8 +16,12 @@ _dcos_marathon() {
     local commands=(
     "about"
     "app"
+    "apply"
     "deployment"
+    "events"
+    "export"
     "group"
+    "import"
     "leader"
     "ping"
     "plugin"
@@ -45,6 +49,107 @@ _dcos_marathon_about() {
     return
 }
 
+_dcos_marathon_apply() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--dry-run"
+    "--filename="
+    "--force"
+    "--prune="
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                ;;
+        esac
+        return
+    fi
+
+}
+
+_dcos_marathon_events() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--app="
+    "--json"
+    "--type="
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                ;;
+        esac
+        return
+    fi
+
+}
+
+_dcos_marathon_export() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--group="
+    "--output="
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                ;;
+        esac
+        return
+    fi
+
+}
+
+_dcos_marathon_import() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--timeout="
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                ;;
+        esac
+        return
+    fi
+}
+
 _dcos_marathon_app() {
     local i command
 
@@ -54,14 +159,19 @@ _dcos_marathon_app() {
 
     local commands=(
     "add"
+    "deploy"
+    "diff"
     "list"
     "remove"
     "restart"
+    "rollback"
+    "scale"
     "show"
     "start"
     "stop"
     "kill"
     "update"
+    "validate"
     "version"
     )
 
@@ -81,7 +191,83 @@ _dcos_marathon_app() {
 }
 
 _dcos_marathon_app_add() {
-    return
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--timeout="
+    "--var="
+    "--var-file="
+    "--wait"
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                ;;
+        esac
+        return
+    fi
+
+}
+
+_dcos_marathon_app_deploy() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--step-size="
+    "--strategy="
+    "--timeout="
+    "--var="
+    "--var-file="
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                ;;
+        esac
+        return
+    fi
+}
+
+_dcos_marathon_app_diff() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--file="
+    "--from="
+    "--to="
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                ;;
+        esac
+        return
+    fi
+
 }
 
 _dcos_marathon_app_list() {
@@ -92,8 +278,16 @@ _dcos_marathon_app_list() {
     fi
 
     local flags=(
+    "--columns="
+    "--deploying"
+    "--group="
     "--json"
+    "--label="
     "--quiet"
+    "--role="
+    "--sort-by="
+    "--unhealthy"
+    "--waiting"
     )
 
     if [ -z "$command" ]; then
@@ -118,6 +312,8 @@ _dcos_marathon_app_remove() {
 
     local flags=(
     "--force"
+    "--timeout="
+    "--wait"
     )
 
     if [ -z "$command" ]; then
@@ -141,6 +337,8 @@ _dcos_marathon_app_restart() {
 
     local flags=(
     "--force"
+    "--timeout="
+    "--wait"
     )
 
     if [ -z "$command" ]; then
@@ -155,6 +353,35 @@ _dcos_marathon_app_restart() {
     fi
 }
 
+_dcos_marathon_app_rollback() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--force"
+    "--steps="
+    "--timeout="
+    "--to-version="
+    "--wait"
+    "--yes"
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                ;;
+        esac
+        return
+    fi
+
+}
+
 _dcos_marathon_app_show() {
     local i command
 
@@ -178,6 +405,31 @@ _dcos_marathon_app_show() {
     fi
 }
 
+_dcos_marathon_app_scale() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--force"
+    "--timeout="
+    "--wait"
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                ;;
+        esac
+        return
+    fi
+}
+
 _dcos_marathon_app_start() {
     local i command
 
@@ -187,6 +439,8 @@ _dcos_marathon_app_start() {
 
     local flags=(
     "--force"
+    "--timeout="
+    "--wait"
     )
 
     if [ -z "$command" ]; then
@@ -211,6 +465,8 @@ _dcos_marathon_app_stop() {
 
     local flags=(
     "--force"
+    "--timeout="
+    "--wait"
     )
 
     if [ -z "$command" ]; then
@@ -257,7 +513,14 @@ _dcos_marathon_app_update() {
     fi
 
     local flags=(
+    "--file="
     "--force"
+    "--patch="
+    "--replace"
+    "--timeout="
+    "--var="
+    "--var-file="
+    "--wait"
     )
 
     if [ -z "$command" ]; then
@@ -272,6 +535,10 @@ _dcos_marathon_app_update() {
     fi
 }
 
+_dcos_marathon_app_validate() {
+    return
+}
+
 _dcos_marathon_app_version() {
     local i command
 
@@ -390,8 +657,7 @@ _dcos_marathon_deployment_watch() {
     fi
 
     local flags=(
-    "--max-count="
-    "--interval="
+    "--timeout="
     )
 
     if [ -z "$command" ]; then
@@ -438,7 +704,28 @@ _dcos_marathon_group() {
 }
 
 _dcos_marathon_group_add() {
-    return
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--id="
+    "--timeout="
+    "--wait"
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                ;;
+        esac
+        return
+    fi
 }
 
 _dcos_marathon_group_list() {
@@ -473,6 +760,8 @@ _dcos_marathon_group_scale() {
 
     local flags=(
     "--force"
+    "--timeout="
+    "--wait"
     )
 
     if [ -z "$command" ]; then
@@ -520,6 +809,8 @@ _dcos_marathon_group_remove() {
 
     local flags=(
     "--force"
+    "--timeout="
+    "--wait"
     )
 
     if [ -z "$command" ]; then
@@ -543,6 +834,8 @@ _dcos_marathon_group_update() {
 
     local flags=(
     "--force"
+    "--timeout="
+    "--wait"
     )
 
     if [ -z "$command" ]; then
@@ -697,6 +990,7 @@ _dcos_marathon_pod() {
     "remove"
     "show"
     "update"
+    "validate"
     )
 
     if [ -z "$command" ]; then
@@ -713,7 +1007,27 @@ _dcos_marathon_pod() {
 }
 
 _dcos_marathon_pod_add() {
-    return
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--timeout="
+    "--wait"
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                ;;
+        esac
+        return
+    fi
 }
 
 _dcos_marathon_pod_kill() {
@@ -753,6 +1067,8 @@ _dcos_marathon_pod_remove() {
 
     local flags=(
     "--force"
+    "--timeout="
+    "--wait"
     )
 
     if [ -z "$command" ]; then
@@ -780,6 +1096,8 @@ _dcos_marathon_pod_update() {
 
     local flags=(
     "--force"
+    "--timeout="
+    "--wait"
     )
 
     if [ -z "$command" ]; then
@@ -794,6 +1112,10 @@ _dcos_marathon_pod_update() {
     fi
 }
 
+_dcos_marathon_pod_validate() {
+    return
+}
+
 _dcos_marathon_debug() {
     local i command
 
@@ -805,6 +1127,7 @@ _dcos_marathon_debug() {
     "list"
     "summary"
     "details"
+    "simulate"
     )
 
     if [ -z "$command" ]; then
@@ -926,8 +1249,11 @@ _dcos_marathon_task_list() {
     fi
 
     local flags=(
+    "--agent="
+    "--health="
     "--json"
     "--quiet"
+    "--state="
     )
 
     if [ -z "$command" ]; then
@@ -994,3 +1320,7 @@ _dcos_marathon_task_kill() {
 _dcos_marathon_task_show() {
     return
 }
+
+_dcos_marathon_debug_simulate() {
+    return
+}
@@ -207,6 +207,7 @@ _dcos_node_log() {
     "--mesos-id="
     "--component="
     "--filter="
+    "--no-reconnect"
     )
 
     if [ -z "$command" ]; then
@@ -45,6 +45,7 @@ _dcos_service_log() {
     local flags=(
     "--follow"
     "--lines="
+    "--no-reconnect"
     "--ssh-config-file="
     )
 
@@ -17,12 +17,17 @@ _dcos_task() {
 
     local commands=(
     "attach"
+    "cat"
+    "cp"
     "download"
     "exec"
     "list"
     "log"
     "ls"
     "metrics"
+    "port-forward"
+    "replay"
+    "tail"
     )
 
     if [ -z "$command" ]; then
@@ -49,6 +54,7 @@ _dcos_task_attach() {
 
     local flags=(
     "--no-stdin"
+    "--record="
     )
 
     if [ -z "$command" ]; then
@@ -64,6 +70,54 @@ _dcos_task_attach() {
     fi
 }
 
+_dcos_task_cat() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--all"
+    "--completed"
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                __dcos_complete_task_ids
+                ;;
+        esac
+        return
+    fi
+}
+
+_dcos_task_cp() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--user"
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                ;;
+        esac
+        return
+    fi
+}
+
 _dcos_task_download() {
     local i command
 
@@ -72,6 +126,8 @@ _dcos_task_download() {
     fi
 
     local flags=(
+    "--archive="
+    "--parallel="
     "--target-dir="
     )
 
@@ -96,7 +152,10 @@ _dcos_task_exec() {
     fi
 
     local flags=(
+    "--all-matching"
     "--interactive"
+    "--parallel="
+    "--record="
     "--tty"
     "--user"
     )
@@ -151,7 +210,12 @@ _dcos_task_log() {
     "--all"
     "--completed"
     "--follow"
+    "--grep="
     "--lines="
+    "--no-reconnect"
+    "--output="
+    "--since="
+    "--until="
     )
 
     if [ -z "$command" ]; then
@@ -268,6 +332,83 @@ _dcos_task_metrics_summary() {
 
 }
 
+_dcos_task_port_forward() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--address="
+    "--user"
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                __dcos_complete_task_ids
+                ;;
+        esac
+        return
+    fi
+}
+
+_dcos_task_replay() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--idle-limit="
+    "--speed="
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                ;;
+        esac
+        return
+    fi
+}
+
+_dcos_task_tail() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=(
+    "--all"
+    "--completed"
+    "--follow"
+    "--interval="
+    "--lines="
+    )
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                __dcos_complete_task_ids
+                ;;
+        esac
+        return
+    fi
+}
+
 __dcos_complete_task_ids() {
     while IFS=$'\n' read -r line; do task_ids+=("$line"); done < <(dcos task list --quiet 2> /dev/null)
     __dcos_handle_compreply "${task_ids[@]}"
@@ -66,7 +66,7 @@ func TestAppAdd(t *testing.T) {
 			var output strings.Builder
 			ctx := mock.NewContext(&cli.Environment{Input: strings.NewReader(tt.input), Out: &output})
 			client := marathon.Client{API: &marathonMock}
-			err := marathonAppAdd(ctx, client, tt.location)
+			err := marathonAppAdd(ctx, client, tt.location, nil, marathon.WaitOptions{})
 
 			if tt.err {
 				assert.Error(t, err)
@@ -1,10 +1,17 @@
 package app
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
+// healthPollInterval is the interval at which the health of the apps is checked.
+var healthPollInterval = 5 * time.Second
+
 func NewCmdMarathonApp(ctx api.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "app",
@@ -13,16 +20,91 @@ func NewCmdMarathonApp(ctx api.Context) *cobra.Command {
 
 	cmd.AddCommand(
 		newCmdMarathonAppAdd(ctx),
+		newCmdMarathonAppDeploy(ctx),
+		newCmdMarathonAppDiff(ctx),
 		newCmdMarathonAppKill(ctx),
 		newCmdMarathonAppList(ctx),
 		newCmdMarathonAppRemove(ctx),
 		newCmdMarathonAppRestart(ctx),
+		newCmdMarathonAppRollback(ctx),
+		newCmdMarathonAppScale(ctx),
 		newCmdMarathonAppShow(ctx),
 		newCmdMarathonAppStart(ctx),
 		newCmdMarathonAppStop(ctx),
 		newCmdMarathonAppUpdate(ctx),
+		newCmdMarathonAppValidate(ctx),
 		newCmdMarathonAppVersion(ctx),
 	)
 
 	return cmd
 }
+
+// addWaitFlags adds the --wait and --timeout flags to an app command, which also waits for the app to be healthy.
+func addWaitFlags(cmd *cobra.Command, opts *marathon.WaitOptions) {
+	marathon.AddWaitFlags(cmd, opts)
+	cmd.Flags().Lookup("wait").Usage = "Wait for the deployment to finish and the app instances to be running and healthy."
+}
+
+// waitForApp follows a deployment until it finishes, then waits for the app to run its target
+// number of healthy instances. The timeout applies to both and a zero timeout means no timeout.
+func waitForApp(ctx api.Context, client *marathon.Client, events <-chan *marathon.Event, deploymentID string, appID string, timeout time.Duration) error {
+	start := time.Now()
+	if err := client.WaitForDeployment(ctx.Out(), events, deploymentID, timeout); err != nil {
+		return err
+	}
+
+	var remaining time.Duration
+	if timeout > 0 {
+		remaining = timeout - time.Since(start)
+		if remaining <= 0 {
+			return marathon.NewTimeoutError("timed out waiting for app '%s' after %s", appID, timeout)
+		}
+	}
+
+	app, err := client.API.Application(appID)
+	if err != nil {
+		return err
+	}
+	instances := 0
+	if app.Instances != nil {
+		instances = *app.Instances
+	}
+	return waitForHealthyInstances(ctx, client, marathon.NormalizeAppID(appID), instances, remaining)
+}
+
+// waitForHealthyInstances waits for the app to have no deployment in progress and the given number
+// of healthy instances, or no running instance at all when that number is 0. Instances of apps without
+// health checks are considered healthy once running. A zero timeout means no timeout.
+func waitForHealthyInstances(ctx api.Context, client *marathon.Client, appID string, instances int, timeout time.Duration) error {
+	fmt.Fprintf(ctx.Out(), "Waiting for app %s to have %d healthy instance(s)\n", appID, instances)
+
+	deadline := time.Now().Add(timeout)
+	for {
+		applications, err := client.Applications()
+		if err != nil {
+			return err
+		}
+
+		for _, app := range applications {
+			if marathon.NormalizeAppID(app.ID) != appID {
+				continue
+			}
+			healthy := app.TasksRunning
+			if app.HealthChecks != nil && len(*app.HealthChecks) > 0 {
+				healthy = app.TasksHealthy
+			}
+			ready := healthy >= instances
+			if instances == 0 {
+				ready = app.TasksRunning == 0
+			}
+			if len(app.Deployments) == 0 && ready {
+				return nil
+			}
+		}
+
+		if timeout > 0 && time.Now().After(deadline) {
+			return marathon.NewTimeoutError("timeout waiting for app '%s' to have %d healthy instance(s)", appID, instances)
+		}
+		time.Sleep(healthPollInterval)
+	}
+}
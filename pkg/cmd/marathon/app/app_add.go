@@ -11,26 +11,55 @@ import (
 )
 
 func newCmdMarathonAppAdd(ctx api.Context) *cobra.Command {
+	var vars []string
+	var varFile string
+	var waitOpts marathon.WaitOptions
+
 	cmd := &cobra.Command{
 		Use:   "add",
 		Short: "Add an application.",
-		Args:  cobra.MaximumNArgs(1),
+		Long: `Add an application.
+
+When --var or --var-file is given, the definition is rendered as a Go
+template before being deployed. Variables are accessible as {{ .name }},
+using a variable which isn't set is an error. Templates can use the
+following functions:
+
+  env "NAME"               the value of an environment variable
+  default "name" "value"   the variable name, or value when it isn't set
+  json .name               the JSON encoding of a value`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := marathon.NewClient(ctx)
 			if err != nil {
 				return err
 			}
+			templateVars, err := marathon.TemplateVars(varFile, vars)
+			if err != nil {
+				return err
+			}
 			if len(args) > 0 {
-				return marathonAppAdd(ctx, *client, args[0])
+				return marathonAppAdd(ctx, *client, args[0], templateVars, waitOpts)
 			}
-			return marathonAppAdd(ctx, *client, "")
+			return marathonAppAdd(ctx, *client, "", templateVars, waitOpts)
 		},
 	}
+
+	cmd.Flags().StringArrayVar(&vars, "var", nil, "Template variable in the form key=value, can be repeated.")
+	cmd.Flags().StringVar(&varFile, "var-file", "", "Path to a YAML or JSON file of template variables.")
+	addWaitFlags(cmd, &waitOpts)
+
 	return cmd
 }
 
-func marathonAppAdd(ctx api.Context, client marathon.Client, appFile string) error {
-	app, err := client.AddApp(ctx, appFile)
+func marathonAppAdd(ctx api.Context, client marathon.Client, appFile string, vars map[string]interface{}, waitOpts marathon.WaitOptions) error {
+	events, unsubscribe, err := client.SubscribeDeploymentEvents(waitOpts)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	app, err := client.AddApp(ctx, appFile, vars)
 	if err != nil {
 		if err == marathon.ErrCannotReadAppDefinition {
 			return fmt.Errorf("can't read from resource: %s. Please check that it exists", appFile)
@@ -39,6 +68,10 @@ func marathonAppAdd(ctx api.Context, client marathon.Client, appFile string) err
 		}
 		return err
 	}
-	fmt.Fprintf(ctx.Out(), "Created deployment %s\n", app.Deployments[0]["id"])
-	return nil
+	deploymentID := fmt.Sprint(app.Deployments[0]["id"])
+	fmt.Fprintf(ctx.Out(), "Created deployment %s\n", deploymentID)
+	if !waitOpts.Wait {
+		return nil
+	}
+	return waitForApp(ctx, &client, events, deploymentID, app.ID, waitOpts.Timeout)
 }
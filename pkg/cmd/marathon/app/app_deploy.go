@@ -0,0 +1,304 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	goMarathon "github.com/gambol99/go-marathon"
+	"github.com/spf13/cobra"
+)
+
+const (
+	strategyBlueGreen = "bluegreen"
+
+	// bluegreenBaseIDLabel and bluegreenColorLabel are set on the apps deployed by a blue/green
+	// deployment, with the ID of the app as defined by the user and the color of the copy.
+	bluegreenBaseIDLabel = "BLUEGREEN_BASE_ID"
+	bluegreenColorLabel  = "BLUEGREEN_COLOR"
+)
+
+type deployOptions struct {
+	strategy string
+	stepSize int
+	timeout  time.Duration
+	vars     []string
+	varFile  string
+}
+
+func newCmdMarathonAppDeploy(ctx api.Context) *cobra.Command {
+	var opts deployOptions
+
+	cmd := &cobra.Command{
+		Use:   "deploy --strategy bluegreen <app-resource>",
+		Short: "Deploy a new version of an application with a deployment strategy.",
+		Long: `Deploy a new version of an application with a deployment strategy.
+
+The bluegreen strategy deploys the definition as a copy of the app, with its ID
+suffixed by -blue or -green, next to the current version of the app. Instances
+are then shifted from the current version to the new one by --step-size at a
+time, waiting for the new instances to be healthy before scaling the current
+version down. Once all instances have been shifted, the current version is
+removed. On failure, the new copy is removed and the current version is scaled
+back to its number of instances.
+
+The host and service ports of the copy are dynamic, so that its instances can
+run on the same agents as the ones of the current version.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.strategy != strategyBlueGreen {
+				return fmt.Errorf("unsupported strategy '%s', only '%s' is supported", opts.strategy, strategyBlueGreen)
+			}
+			if opts.stepSize < 1 {
+				return errors.New("the step size must be greater than 0")
+			}
+
+			templateVars, err := marathon.TemplateVars(opts.varFile, opts.vars)
+			if err != nil {
+				return err
+			}
+			definition, err := loadDefinition(ctx, args[0], templateVars)
+			if err != nil {
+				return err
+			}
+
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			return appDeployBlueGreen(ctx, client, definition, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.strategy, "strategy", "", "Deployment strategy to use, only bluegreen is supported.")
+	cmd.Flags().IntVar(&opts.stepSize, "step-size", 1, "Number of instances to shift from the current version to the new one at each step.")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 10*time.Minute, "Maximum time to wait for the new instances of each step to be healthy.")
+	cmd.Flags().StringArrayVar(&opts.vars, "var", nil, "Template variable in the form key=value, can be repeated.")
+	cmd.Flags().StringVar(&opts.varFile, "var-file", "", "Path to a YAML or JSON file of template variables.")
+	cmd.MarkFlagRequired("strategy")
+
+	return cmd
+}
+
+func appDeployBlueGreen(ctx api.Context, client *marathon.Client, definition map[string]interface{}, opts deployOptions) error {
+	id, ok := definition["id"].(string)
+	if !ok || id == "" {
+		return errors.New("application ID must be set")
+	}
+	baseID := marathon.NormalizeAppID(id)
+
+	applications, err := client.Applications()
+	if err != nil {
+		return err
+	}
+	current, color, err := currentBlueGreenApp(applications, baseID)
+	if err != nil {
+		return err
+	}
+
+	target := 1
+	if instances, ok := definition["instances"].(float64); ok {
+		target = int(instances)
+	}
+	newID := baseID + "-" + color
+
+	currentInstances := 0
+	if current != nil {
+		if current.Instances != nil {
+			currentInstances = *current.Instances
+		}
+		fmt.Fprintf(ctx.Out(), "Deploying %s alongside %s with %d instance(s), %d per step\n", newID, current.ID, target, opts.stepSize)
+	} else {
+		fmt.Fprintf(ctx.Out(), "Deploying %s with %d instance(s), %d per step\n", newID, target, opts.stepSize)
+	}
+
+	app := copyMap(definition)
+	labels := make(map[string]interface{})
+	if existing, ok := definition["labels"].(map[string]interface{}); ok {
+		for key, value := range existing {
+			labels[key] = value
+		}
+	}
+	labels[bluegreenBaseIDLabel] = baseID
+	labels[bluegreenColorLabel] = color
+	app["id"] = newID
+	app["labels"] = labels
+	app["instances"] = minInt(opts.stepSize, target)
+	dynamicPorts(app)
+
+	deploymentID, err := client.CreateApp(app)
+	if err != nil {
+		return fmt.Errorf("unable to create app '%s': %s", newID, err)
+	}
+	fmt.Fprintf(ctx.Out(), "Created deployment %s to create app %s with %d instance(s)\n", deploymentID, newID, app["instances"])
+
+	err = shiftInstances(ctx, client, newID, app["instances"].(int), target, current, currentInstances, opts)
+	if err != nil {
+		fmt.Fprintf(ctx.Out(), "Rolling back: removing app %s", newID)
+		if current != nil {
+			fmt.Fprintf(ctx.Out(), " and scaling app %s back to %d instance(s)", current.ID, currentInstances)
+		}
+		fmt.Fprintln(ctx.Out())
+
+		if rollbackErr := rollbackBlueGreen(client, newID, current, currentInstances); rollbackErr != nil {
+			return fmt.Errorf("blue/green deployment failed: %s, rollback failed: %s", err, rollbackErr)
+		}
+		return fmt.Errorf("blue/green deployment failed: %s", err)
+	}
+
+	if current != nil {
+		deploymentID, err := client.API.DeleteApplication(current.ID, false)
+		if err != nil {
+			return fmt.Errorf("unable to remove app '%s': %s", current.ID, err)
+		}
+		fmt.Fprintf(ctx.Out(), "Created deployment %s to remove app %s\n", deploymentID.DeploymentID, current.ID)
+	}
+	fmt.Fprintf(ctx.Out(), "Blue/green deployment of %s finished, %s is running %d instance(s).\n", baseID, newID, target)
+	return nil
+}
+
+// currentBlueGreenApp returns the app currently deployed for baseID, if any, and the color of its new copy.
+func currentBlueGreenApp(applications []goMarathon.Application, baseID string) (*goMarathon.Application, string, error) {
+	found := make(map[string]*goMarathon.Application)
+	for i, app := range applications {
+		switch marathon.NormalizeAppID(app.ID) {
+		case baseID:
+			found[""] = &applications[i]
+		case baseID + "-blue":
+			found["blue"] = &applications[i]
+		case baseID + "-green":
+			found["green"] = &applications[i]
+		}
+	}
+
+	if len(found) > 1 {
+		return nil, "", fmt.Errorf("more than one version of %s is deployed, a blue/green deployment may be in progress", baseID)
+	}
+	if current, ok := found["blue"]; ok {
+		return current, "green", nil
+	}
+	if current, ok := found["green"]; ok {
+		return current, "blue", nil
+	}
+	return found[""], "blue", nil
+}
+
+// shiftInstances scales the new app up and the current one down by opts.stepSize instances
+// at a time, waiting for the new app to be healthy at each step.
+func shiftInstances(ctx api.Context, client *marathon.Client, newID string, instances int, target int,
+	current *goMarathon.Application, remaining int, opts deployOptions) error {
+	for {
+		if err := waitForHealthyInstances(ctx, client, newID, instances, opts.timeout); err != nil {
+			return err
+		}
+
+		// The current version is removed once all the instances have been shifted.
+		if instances == target {
+			return nil
+		}
+
+		if current != nil && remaining > 0 {
+			remaining = maxInt(remaining-opts.stepSize, 0)
+			deploymentID, err := client.API.ScaleApplicationInstances(current.ID, remaining, false)
+			if err != nil {
+				return fmt.Errorf("unable to scale app '%s': %s", current.ID, err)
+			}
+			fmt.Fprintf(ctx.Out(), "Created deployment %s to scale app %s to %d instance(s)\n", deploymentID.DeploymentID, current.ID, remaining)
+		}
+
+		instances = minInt(instances+opts.stepSize, target)
+		deploymentID, err := client.API.ScaleApplicationInstances(newID, instances, false)
+		if err != nil {
+			return fmt.Errorf("unable to scale app '%s': %s", newID, err)
+		}
+		fmt.Fprintf(ctx.Out(), "Created deployment %s to scale app %s to %d instance(s)\n", deploymentID.DeploymentID, newID, instances)
+	}
+}
+
+// dynamicPorts sets the fixed ports of an app definition to 0, Marathon then assigns them dynamically.
+// The nested definitions are copied rather than modified in place.
+func dynamicPorts(app map[string]interface{}) {
+	if ports, ok := app["ports"].([]interface{}); ok {
+		zeros := make([]interface{}, len(ports))
+		for i := range zeros {
+			zeros[i] = 0
+		}
+		app["ports"] = zeros
+	}
+	if portDefinitions, ok := app["portDefinitions"].([]interface{}); ok {
+		app["portDefinitions"] = zeroPortFields(portDefinitions, "port")
+	}
+
+	container, ok := app["container"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	container = copyMap(container)
+	app["container"] = container
+	if portMappings, ok := container["portMappings"].([]interface{}); ok {
+		container["portMappings"] = zeroPortFields(portMappings, "hostPort", "servicePort")
+	}
+	if docker, ok := container["docker"].(map[string]interface{}); ok {
+		docker = copyMap(docker)
+		container["docker"] = docker
+		if portMappings, ok := docker["portMappings"].([]interface{}); ok {
+			docker["portMappings"] = zeroPortFields(portMappings, "hostPort", "servicePort")
+		}
+	}
+}
+
+// zeroPortFields returns a copy of a list of port definitions or mappings with the given fields set to 0.
+func zeroPortFields(ports []interface{}, fields ...string) []interface{} {
+	result := make([]interface{}, len(ports))
+	for i, port := range ports {
+		portMap, ok := port.(map[string]interface{})
+		if !ok {
+			result[i] = port
+			continue
+		}
+		portMap = copyMap(portMap)
+		for _, field := range fields {
+			if _, ok := portMap[field]; ok {
+				portMap[field] = 0
+			}
+		}
+		result[i] = portMap
+	}
+	return result
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		result[key] = value
+	}
+	return result
+}
+
+func rollbackBlueGreen(client *marathon.Client, newID string, current *goMarathon.Application, instances int) error {
+	if _, err := client.API.DeleteApplication(newID, true); err != nil {
+		return err
+	}
+	if current != nil {
+		if _, err := client.API.ScaleApplicationInstances(current.ID, instances, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	goMarathon "github.com/gambol99/go-marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bluegreenServer is a fake Marathon keeping track of the instances of the apps.
+// Apps are reported healthy once deployed, unless they are listed in unhealthy.
+type bluegreenServer struct {
+	mu        sync.Mutex
+	instances map[string]int
+	labels    map[string]map[string]string
+	unhealthy map[string]bool
+	calls     []string
+}
+
+func (s *bluegreenServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := strings.TrimPrefix(r.URL.Path, "/service/marathon/v2/apps")
+	switch {
+	case r.Method == http.MethodGet && id == "":
+		var apps []goMarathon.Application
+		for appID, instances := range s.instances {
+			instances := instances
+			healthy := instances
+			if s.unhealthy[appID] {
+				healthy = 0
+			}
+			apps = append(apps, goMarathon.Application{
+				ID:           appID,
+				Instances:    &instances,
+				TasksRunning: instances,
+				TasksHealthy: healthy,
+				HealthChecks: &[]goMarathon.HealthCheck{{}},
+			})
+		}
+		sort.Slice(apps, func(i, j int) bool { return apps[i].ID < apps[j].ID })
+		json.NewEncoder(w).Encode(goMarathon.Applications{Apps: apps})
+		return
+	case r.Method == http.MethodPost && id == "":
+		var app struct {
+			ID        string            `json:"id"`
+			Instances int               `json:"instances"`
+			Labels    map[string]string `json:"labels"`
+		}
+		json.NewDecoder(r.Body).Decode(&app)
+		s.instances[app.ID] = app.Instances
+		s.labels[app.ID] = app.Labels
+		s.calls = append(s.calls, "create "+app.ID+" "+strconv.Itoa(app.Instances))
+		w.Header().Set("Marathon-Deployment-Id", "d")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+		return
+	case r.Method == http.MethodPut:
+		var app goMarathon.Application
+		json.NewDecoder(r.Body).Decode(&app)
+		s.instances[id] = *app.Instances
+		s.calls = append(s.calls, "scale "+id+" "+strconv.Itoa(*app.Instances))
+	case r.Method == http.MethodDelete:
+		delete(s.instances, id)
+		s.calls = append(s.calls, "remove "+id)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(goMarathon.DeploymentID{DeploymentID: "d"})
+}
+
+func TestAppDeployBlueGreen(t *testing.T) {
+	healthPollInterval = time.Millisecond
+	server := &bluegreenServer{
+		instances: map[string]int{"/prod/web-blue": 3},
+		labels:    make(map[string]map[string]string),
+	}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	definition := map[string]interface{}{"id": "/prod/web", "instances": float64(3), "labels": map[string]interface{}{"team": "web"}}
+	err = appDeployBlueGreen(ctx, client, definition, deployOptions{stepSize: 2, timeout: time.Second})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"create /prod/web-green 2",
+		"scale /prod/web-blue 1",
+		"scale /prod/web-green 3",
+		"remove /prod/web-blue",
+	}, server.calls)
+	assert.Equal(t, map[string]int{"/prod/web-green": 3}, server.instances)
+	assert.Equal(t, map[string]string{"team": "web", "BLUEGREEN_BASE_ID": "/prod/web", "BLUEGREEN_COLOR": "green"}, server.labels["/prod/web-green"])
+	assert.Contains(t, out.String(), "Blue/green deployment of /prod/web finished, /prod/web-green is running 3 instance(s).\n")
+}
+
+func TestDynamicPorts(t *testing.T) {
+	definition := map[string]interface{}{
+		"id":              "/web",
+		"requirePorts":    true,
+		"ports":           []interface{}{float64(8080)},
+		"portDefinitions": []interface{}{map[string]interface{}{"port": float64(8080), "name": "http"}},
+		"container": map[string]interface{}{
+			"portMappings": []interface{}{map[string]interface{}{"containerPort": float64(80), "hostPort": float64(8080)}},
+			"docker": map[string]interface{}{
+				"image":        "nginx",
+				"portMappings": []interface{}{map[string]interface{}{"containerPort": float64(80), "servicePort": float64(10000)}},
+			},
+		},
+	}
+	app := copyMap(definition)
+	dynamicPorts(app)
+
+	assert.Equal(t, map[string]interface{}{
+		"id":              "/web",
+		"requirePorts":    true,
+		"ports":           []interface{}{0},
+		"portDefinitions": []interface{}{map[string]interface{}{"port": 0, "name": "http"}},
+		"container": map[string]interface{}{
+			"portMappings": []interface{}{map[string]interface{}{"containerPort": float64(80), "hostPort": 0}},
+			"docker": map[string]interface{}{
+				"image":        "nginx",
+				"portMappings": []interface{}{map[string]interface{}{"containerPort": float64(80), "servicePort": 0}},
+			},
+		},
+	}, app)
+	assert.Equal(t, []interface{}{float64(8080)}, definition["ports"], "the definition must not be modified")
+}
+
+func TestAppDeployBlueGreenRollback(t *testing.T) {
+	healthPollInterval = time.Millisecond
+	server := &bluegreenServer{
+		instances: map[string]int{"/prod/web": 2},
+		labels:    make(map[string]map[string]string),
+		unhealthy: map[string]bool{"/prod/web-blue": true},
+	}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	definition := map[string]interface{}{"id": "/prod/web", "instances": float64(2)}
+	err = appDeployBlueGreen(ctx, client, definition, deployOptions{stepSize: 1, timeout: 10 * time.Millisecond})
+	assert.EqualError(t, err, "blue/green deployment failed: timeout waiting for app '/prod/web-blue' to have 1 healthy instance(s)")
+
+	assert.Equal(t, []string{
+		"create /prod/web-blue 1",
+		"remove /prod/web-blue",
+		"scale /prod/web 2",
+	}, server.calls)
+	assert.Contains(t, out.String(), "Rolling back: removing app /prod/web-blue and scaling app /prod/web back to 2 instance(s)\n")
+}
+
+func TestAppDeployBlueGreenInProgress(t *testing.T) {
+	server := &bluegreenServer{instances: map[string]int{"/prod/web-blue": 1, "/prod/web-green": 1}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	ctx, _ := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appDeployBlueGreen(ctx, client, map[string]interface{}{"id": "/prod/web"}, deployOptions{stepSize: 1})
+	assert.EqualError(t, err, "more than one version of /prod/web is deployed, a blue/green deployment may be in progress")
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/spf13/cobra"
+)
+
+type appDiffOptions struct {
+	from    string
+	to      string
+	appFile string
+}
+
+func newCmdMarathonAppDiff(ctx api.Context) *cobra.Command {
+	var opts appDiffOptions
+
+	cmd := &cobra.Command{
+		Use:   "diff <app-id>",
+		Short: "Show the differences between two versions of an application.",
+		Long: `Show the differences between two versions of an application.
+
+Without any flag, the currently deployed definition is compared with the
+previous one. --to compares with another version and --file with a local
+definition; in both cases --from defaults to the currently deployed
+definition. Fields managed by Marathon such as version and versionInfo
+are ignored.
+
+Versions can be specified as absolute or relative values. Absolute values
+must be in ISO8601 date format. Relative values must be specified as a
+negative integer and they represent the version from the currently
+deployed application definition.`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.to != "" && opts.appFile != "" {
+				return fmt.Errorf("the flags 'to' and 'file' cannot be used together")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			return appDiff(ctx, client, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.from, "from", "", "The version of the application to compare from.")
+	cmd.Flags().StringVar(&opts.to, "to", "", "The version of the application to compare to.")
+	cmd.Flags().StringVar(&opts.appFile, "file", "", "Path or URL to a local app definition to compare to.")
+
+	return cmd
+}
+
+func appDiff(ctx api.Context, client *marathon.Client, appID string, opts appDiffOptions) error {
+	from := opts.from
+	if from == "" && opts.to == "" && opts.appFile == "" {
+		from = "-1"
+	}
+
+	fromApp, fromLabel, err := appVersionDefinition(client, appID, from)
+	if err != nil {
+		return err
+	}
+
+	var toApp map[string]interface{}
+	var toLabel string
+	if opts.appFile != "" {
+		toApp, err = loadDefinition(ctx, opts.appFile, nil)
+		if err != nil {
+			return err
+		}
+		toApp = marathon.StripServerFields(toApp)
+		if _, ok := toApp["id"]; !ok {
+			toApp["id"] = fromApp["id"]
+		}
+		toLabel = opts.appFile
+	} else {
+		toApp, toLabel, err = appVersionDefinition(client, appID, opts.to)
+		if err != nil {
+			return err
+		}
+	}
+
+	changes := marathon.Diff(fromApp, toApp)
+	if len(changes) == 0 {
+		fmt.Fprintf(ctx.Out(), "No differences between %s and %s.\n", fromLabel, toLabel)
+		return nil
+	}
+
+	fmt.Fprintf(ctx.Out(), "--- %s\n+++ %s\n", fromLabel, toLabel)
+	marathon.PrintDiff(ctx.Out(), changes, marathon.IsColored(ctx.Out()))
+	return nil
+}
+
+// appVersionDefinition returns the definition of an app at the given absolute or relative version
+// without its server-managed fields, along with the version it corresponds to.
+func appVersionDefinition(client *marathon.Client, appID string, version string) (map[string]interface{}, string, error) {
+	targetVersion := ""
+	if version != "" {
+		var err error
+		targetVersion, err = calculateVersion(client, appID, version)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	app, err := client.RawApplication(appID, targetVersion)
+	if err != nil {
+		return nil, "", err
+	}
+
+	label := fmt.Sprintf("%s@%v", marathon.NormalizeAppID(appID), app["version"])
+	return marathon.StripServerFields(app), label, nil
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func diffServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		switch r.URL.Path {
+		case "/service/marathon/v2/apps/test":
+			w.Write([]byte(`{"app":{"id":"/test","cmd":"sleep 200","instances":2,"version":"2020-01-03T00:00:00.000Z","tasksRunning":2}}`))
+		case "/service/marathon/v2/apps/test/versions":
+			w.Write([]byte(`{"versions":["2020-01-03T00:00:00.000Z","2020-01-02T00:00:00.000Z","2020-01-01T00:00:00.000Z"]}`))
+		case "/service/marathon/v2/apps/test/versions/2020-01-02T00:00:00.000Z":
+			w.Write([]byte(`{"id":"/test","cmd":"sleep 100","instances":2,"version":"2020-01-02T00:00:00.000Z","versionInfo":{}}`))
+		case "/service/marathon/v2/apps/test/versions/2020-01-01T00:00:00.000Z":
+			w.Write([]byte(`{"id":"/test","cmd":"sleep 100","instances":1,"version":"2020-01-01T00:00:00.000Z","versionInfo":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestAppDiffPreviousVersion(t *testing.T) {
+	ts := diffServer(t)
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appDiff(ctx, client, "test", appDiffOptions{})
+	require.NoError(t, err)
+
+	expected := "--- /test@2020-01-02T00:00:00.000Z\n" +
+		"+++ /test@2020-01-03T00:00:00.000Z\n" +
+		"~ /cmd: \"sleep 100\" -> \"sleep 200\"\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestAppDiffVersions(t *testing.T) {
+	ts := diffServer(t)
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appDiff(ctx, client, "test", appDiffOptions{from: "2020-01-01T00:00:00.000Z", to: "-1"})
+	require.NoError(t, err)
+
+	expected := "--- /test@2020-01-01T00:00:00.000Z\n" +
+		"+++ /test@2020-01-02T00:00:00.000Z\n" +
+		"~ /instances: 1 -> 2\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestAppDiffFile(t *testing.T) {
+	ts := diffServer(t)
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "app-diff")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	appFile := filepath.Join(dir, "app.json")
+	err = ioutil.WriteFile(appFile, []byte(`{"id":"/test","cmd":"sleep 200","instances":2}`), 0600)
+	require.NoError(t, err)
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appDiff(ctx, client, "test", appDiffOptions{appFile: appFile})
+	require.NoError(t, err)
+	assert.Equal(t, "No differences between /test@2020-01-03T00:00:00.000Z and "+appFile+".\n", out.String())
+}
+
+func TestAppDiffUnknownVersion(t *testing.T) {
+	ts := diffServer(t)
+	defer ts.Close()
+
+	ctx, _ := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appDiff(ctx, client, "test", appDiffOptions{from: "-3"})
+	assert.EqualError(t, err, "application 'test' only has 3 version(s)")
+}
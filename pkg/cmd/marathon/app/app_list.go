@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/dcos/dcos-cli/api"
@@ -32,89 +33,267 @@ var deploymentDisplay = map[string]string{
 	"KillAllOldTasksOf":  "kill-tasks",
 }
 
+type appListOptions struct {
+	quiet      bool
+	jsonOutput bool
+	labels     []string
+	role       string
+	group      string
+	unhealthy  bool
+	waiting    bool
+	deploying  bool
+	sortBy     string
+	columns    []string
+}
+
 func newCmdMarathonAppList(ctx api.Context) *cobra.Command {
-	var jsonOutput bool
-	var quiet bool
+	var opts appListOptions
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List the installed applications.",
+		Long: `List the installed applications.
+
+The available columns are id, mem, cpus, tasks, health, deployment, waiting,
+container, cmd and role. The value of a label can be shown in a column named
+label:<key>, e.g. --columns id,tasks,label:team.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := marathon.NewClient(ctx)
 			if err != nil {
 				return err
 			}
 
-			return appList(ctx, client, quiet, jsonOutput)
+			return appList(ctx, client, opts)
 		},
 	}
 
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print JSON-formatted data.")
-	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Display IDs only.")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "Print JSON-formatted data.")
+	cmd.Flags().BoolVarP(&opts.quiet, "quiet", "q", false, "Display IDs only.")
+	cmd.Flags().StringArrayVar(&opts.labels, "label", nil, "Only list the apps with this label, in the form key=value or key. Can be repeated.")
+	cmd.Flags().StringVar(&opts.role, "role", "", "Only list the apps with this role.")
+	cmd.Flags().StringVar(&opts.group, "group", "", "Only list the apps in this group.")
+	cmd.Flags().BoolVar(&opts.unhealthy, "unhealthy", false, "Only list the apps with unhealthy tasks.")
+	cmd.Flags().BoolVar(&opts.waiting, "waiting", false, "Only list the apps waiting for resources to launch tasks.")
+	cmd.Flags().BoolVar(&opts.deploying, "deploying", false, "Only list the apps with a deployment in progress.")
+	cmd.Flags().StringVar(&opts.sortBy, "sort-by", "", "Sort the apps by this column, tables are sorted by ID by default.")
+	cmd.Flags().StringSliceVar(&opts.columns, "columns", defaultAppColumns, "Comma-separated list of the columns to display.")
 
 	return cmd
 }
 
-func appList(ctx api.Context, client *marathon.Client, quiet bool, jsonOutput bool) error {
+// appRow is an app along with the deployments and the queue it is displayed with.
+type appRow struct {
+	app         m.Application
+	deployments map[string]m.Deployment
+	queue       m.Queue
+}
+
+type appColumn struct {
+	header string
+	value  func(row appRow) string
+}
+
+var defaultAppColumns = []string{"id", "mem", "cpus", "tasks", "health", "deployment", "waiting", "container", "cmd", "role"}
+
+var appColumns = map[string]appColumn{
+	"id":         {"ID", func(row appRow) string { return row.app.ID }},
+	"mem":        {"MEM", func(row appRow) string { return formatMem(row.app) }},
+	"cpus":       {"CPUS", func(row appRow) string { return humanize.Ftoa(row.app.CPUs) }},
+	"tasks":      {"TASKS", func(row appRow) string { return formatTaskRunning(row.app) }},
+	"health":     {"HEALTH", func(row appRow) string { return formatHealth(row.app) }},
+	"deployment": {"DEPLOYMENT", func(row appRow) string { return formatDeployments(row.app, row.deployments) }},
+	"waiting":    {"WAITING", func(row appRow) string { return formatWaiting(row.app, row.queue) }},
+	"container":  {"CONTAINER", func(row appRow) string { return formatContainerType(row.app) }},
+	"cmd":        {"CMD", func(row appRow) string { return formatCmd(row.app) }},
+	"role":       {"ROLE", func(row appRow) string { return formatRole(row.app) }},
+}
+
+// normalizeAppColumnName lowercases a column name, except for the key of label:<key> columns
+// as label keys are case-sensitive.
+func normalizeAppColumnName(name string) string {
+	name = strings.TrimSpace(name)
+	if len(name) >= len("label:") && strings.EqualFold(name[:len("label:")], "label:") {
+		return "label:" + name[len("label:"):]
+	}
+	return strings.ToLower(name)
+}
+
+// lookupAppColumn returns the column with the given name, which is either one of appColumns or label:<key>.
+func lookupAppColumn(name string) (appColumn, error) {
+	name = normalizeAppColumnName(name)
+	if column, ok := appColumns[name]; ok {
+		return column, nil
+	}
+
+	if strings.HasPrefix(name, "label:") && len(name) > len("label:") {
+		key := name[len("label:"):]
+		return appColumn{"LABEL:" + key, func(row appRow) string {
+			if row.app.Labels != nil {
+				if value, ok := (*row.app.Labels)[key]; ok {
+					return value
+				}
+			}
+			return defaultTableValue
+		}}, nil
+	}
+	return appColumn{}, fmt.Errorf("unknown column '%s', expected one of %s or label:<key>", name, strings.Join(defaultAppColumns, ", "))
+}
+
+func appList(ctx api.Context, client *marathon.Client, opts appListOptions) error {
+	columns := make([]appColumn, 0, len(opts.columns))
+	for _, name := range opts.columns {
+		column, err := lookupAppColumn(name)
+		if err != nil {
+			return err
+		}
+		columns = append(columns, column)
+	}
+	sortBy := normalizeAppColumnName(opts.sortBy)
+	sortColumn, err := lookupAppColumn("id")
+	if sortBy != "" {
+		sortColumn, err = lookupAppColumn(sortBy)
+	}
+	if err != nil {
+		return err
+	}
+
+	labels := make(map[string]*string)
+	for _, label := range opts.labels {
+		terms := strings.SplitN(label, "=", 2)
+		if len(terms) == 2 {
+			labels[terms[0]] = &terms[1]
+		} else {
+			labels[terms[0]] = nil
+		}
+	}
+
 	applications, err := client.Applications()
 	if err != nil {
 		return err
 	}
 
-	if quiet {
-		for _, app := range applications {
-			fmt.Fprintln(ctx.Out(), app.ID)
+	// The deployments and the queue are only fetched when they are displayed or filtered on.
+	table := !opts.quiet && !opts.jsonOutput
+	deploymentMap := make(map[string]m.Deployment)
+	if table || sortBy == "deployment" {
+		deployments, err := client.Deployments()
+		if err != nil {
+			return err
+		}
+		for _, d := range deployments {
+			deploymentMap[d.ID] = d
+		}
+	}
+
+	var queue m.Queue
+	if table || opts.waiting || sortBy == "waiting" {
+		queue, err = client.Queue()
+		if err != nil {
+			return err
+		}
+	}
+
+	rows := make([]appRow, 0, len(applications))
+	for _, app := range applications {
+		row := appRow{app: app, deployments: deploymentMap, queue: queue}
+		if matchesAppFilters(row, opts, labels) {
+			rows = append(rows, row)
+		}
+	}
+
+	// Apps are listed in the order Marathon returns them unless they're displayed in a table or sorted explicitly.
+	if table || sortBy != "" {
+		sort.SliceStable(rows, func(i, j int) bool {
+			return lessTableValue(sortColumn.value(rows[i]), sortColumn.value(rows[j]))
+		})
+	}
+
+	if opts.quiet {
+		for _, row := range rows {
+			fmt.Fprintln(ctx.Out(), row.app.ID)
 		}
 		return nil
 	}
 
-	if jsonOutput {
+	if opts.jsonOutput {
+		apps := make([]m.Application, 0, len(rows))
+		for _, row := range rows {
+			apps = append(apps, row.app)
+		}
+
 		enc := json.NewEncoder(ctx.Out())
 		enc.SetIndent("", "    ")
 
-		return enc.Encode(&applications)
+		return enc.Encode(&apps)
 	}
 
-	deployments, err := client.Deployments()
-	if err != nil {
-		return err
+	tableHeader := make([]string, 0, len(columns))
+	for _, column := range columns {
+		tableHeader = append(tableHeader, column.header)
 	}
+	tableWriter := cli.NewTable(ctx.Out(), tableHeader)
+	tableWriter.SetAlignment(tablewriter.ALIGN_LEFT)
 
-	deploymentMap := make(map[string]m.Deployment)
-	for _, d := range deployments {
-		deploymentMap[d.ID] = d
+	for _, row := range rows {
+		item := make([]string, 0, len(columns))
+		for _, column := range columns {
+			item = append(item, column.value(row))
+		}
+		tableWriter.Append(item)
 	}
+	tableWriter.Render()
 
-	queue, err := client.Queue()
-	if err != nil {
-		return err
-	}
+	return nil
+}
 
-	tableHeader := []string{"ID", "MEM", "CPUS", "TASKS", "HEALTH", "DEPLOYMENT", "WAITING", "CONTAINER", "CMD", "ROLE"}
-	table := cli.NewTable(ctx.Out(), tableHeader)
-	table.SetAlignment(tablewriter.ALIGN_LEFT)
+func matchesAppFilters(row appRow, opts appListOptions, labels map[string]*string) bool {
+	app := row.app
 
-	items := make([][]string, 0, len(applications))
-	for _, app := range applications {
-		item := []string{
-			app.ID,
-			humanize.Ftoa(*app.Mem),
-			humanize.Ftoa(app.CPUs),
-			formatTaskRunning(app),
-			formatHealth(app),
-			formatDeployments(app, deploymentMap),
-			formatWaiting(app, queue),
-			formatContainerType(app),
-			formatCmd(app),
-			formatRole(app),
+	for key, value := range labels {
+		if app.Labels == nil {
+			return false
+		}
+		actual, ok := (*app.Labels)[key]
+		if !ok || (value != nil && actual != *value) {
+			return false
+		}
+	}
+	if opts.role != "" && formatRole(app) != opts.role {
+		return false
+	}
+	if opts.group != "" {
+		prefix := strings.TrimSuffix(marathon.NormalizeAppID(opts.group), "/") + "/"
+		if !strings.HasPrefix(marathon.NormalizeAppID(app.ID), prefix) {
+			return false
 		}
-		items = append(items, item)
 	}
-	sort.Sort(appRows(items))
-	table.AppendBulk(items)
-	table.Render()
+	if opts.unhealthy && app.TasksUnhealthy == 0 {
+		return false
+	}
+	if opts.waiting && formatWaiting(app, row.queue) != "true" {
+		return false
+	}
+	if opts.deploying && len(app.Deployments) == 0 {
+		return false
+	}
+	return true
+}
 
-	return nil
+// lessTableValue compares two table values, numerically when they are both numbers.
+func lessTableValue(a string, b string) bool {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return x < y
+	}
+	return strings.Compare(a, b) < 0
+}
+
+func formatMem(app m.Application) string {
+	if app.Mem == nil {
+		return defaultTableValue
+	}
+	return humanize.Ftoa(*app.Mem)
 }
 
 func truncate(s string, maxLength int) string {
@@ -186,10 +365,8 @@ func formatDeployments(app m.Application, deployments map[string]m.Deployment) s
 
 func formatWaiting(app m.Application, queue m.Queue) string {
 	for _, item := range queue.Items {
-		if app.ID == item.Application.ID {
-			if item.Delay.Overdue {
-				return "true"
-			}
+		if item.Application != nil && item.Application.ID == app.ID && item.Delay.Overdue {
+			return "true"
 		}
 	}
 	return "false"
@@ -201,18 +378,3 @@ func formatRole(app m.Application) string {
 	}
 	return *app.Role
 }
-
-// this enables sorting by ID
-type appRows [][]string
-
-func (a appRows) Swap(i int, j int) {
-	a[i], a[j] = a[j], a[i]
-}
-
-func (a appRows) Less(i int, j int) bool {
-	return strings.Compare(a[i][0], a[j][0]) < 0
-}
-
-func (a appRows) Len() int {
-	return len(a)
-}
@@ -46,7 +46,7 @@ func TestAppList(t *testing.T) {
 	client, err := marathon.NewClient(ctx)
 	require.NoError(t, err)
 
-	err = appList(ctx, client, false, false)
+	err = appList(ctx, client, appListOptions{columns: defaultAppColumns})
 	assert.NoError(t, err)
 	expected := "    ID     MEM  CPUS  TASKS  HEALTH  DEPLOYMENT  WAITING  CONTAINER    CMD         ROLE      \n" +
 		"  test-id  2    1     3/4    ---     ---         false    MESOS      test cmd  public_agent  \n"
@@ -77,7 +77,7 @@ func TestAppListQuiet(t *testing.T) {
 	client, err := marathon.NewClient(ctx)
 	require.NoError(t, err)
 
-	err = appList(ctx, client, true, false)
+	err = appList(ctx, client, appListOptions{quiet: true})
 	assert.NoError(t, err)
 	assert.Equal(t, "test-id\n", out.String())
 
@@ -107,7 +107,7 @@ func TestAppListJSON(t *testing.T) {
 	client, err := marathon.NewClient(ctx)
 	require.NoError(t, err)
 
-	err = appList(ctx, client, false, true)
+	err = appList(ctx, client, appListOptions{jsonOutput: true})
 	assert.NoError(t, err)
 
 	expected := new(bytes.Buffer)
@@ -119,6 +119,126 @@ func TestAppListJSON(t *testing.T) {
 	assert.Equal(t, expected.String(), out.String())
 }
 
+func TestAppListFilters(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch url := r.URL.String(); url {
+		case "/service/marathon/v2/apps":
+			apps := m.Applications{
+				Apps: []m.Application{
+					{
+						ID:             "/prod/web",
+						Mem:            floatPointer(512),
+						Role:           strPointer("prod"),
+						Labels:         &map[string]string{"team": "web"},
+						TasksUnhealthy: 1,
+						Instances:      intPointer(3),
+					},
+					{
+						ID:          "/prod/api",
+						Mem:         floatPointer(1024),
+						Role:        strPointer("prod"),
+						Labels:      &map[string]string{"team": "api"},
+						Deployments: []map[string]string{{"id": "d1"}},
+						Instances:   intPointer(1),
+					},
+					{
+						ID:        "/dev/web",
+						Mem:       floatPointer(64),
+						Role:      strPointer("dev"),
+						Labels:    &map[string]string{"team": "web"},
+						Instances: intPointer(1),
+					},
+				},
+			}
+			json.NewEncoder(w).Encode(apps)
+		case "/service/marathon/v2/deployments":
+			json.NewEncoder(w).Encode([]m.Deployment{})
+		case "/service/marathon/v2/queue":
+			queue := m.Queue{Items: []m.Item{
+				{Pod: &m.Pod{ID: "/dev/db"}, Delay: m.Delay{Overdue: true}},
+				{Application: &m.Application{ID: "/dev/web"}, Delay: m.Delay{Overdue: true}},
+			}}
+			json.NewEncoder(w).Encode(queue)
+		default:
+			t.Fatalf("unexpected request %s", url)
+		}
+	}))
+	defer ts.Close()
+
+	fixtures := []struct {
+		opts appListOptions
+		ids  string
+	}{
+		{appListOptions{labels: []string{"team=web"}}, "/prod/web\n/dev/web\n"},
+		{appListOptions{labels: []string{"team"}, role: "prod"}, "/prod/web\n/prod/api\n"},
+		{appListOptions{group: "/prod", sortBy: "id"}, "/prod/api\n/prod/web\n"},
+		{appListOptions{unhealthy: true}, "/prod/web\n"},
+		{appListOptions{waiting: true}, "/dev/web\n"},
+		{appListOptions{deploying: true}, "/prod/api\n"},
+		{appListOptions{sortBy: "mem"}, "/dev/web\n/prod/web\n/prod/api\n"},
+	}
+
+	for _, fixture := range fixtures {
+		ctx, out := newContext(ts)
+		client, err := marathon.NewClient(ctx)
+		require.NoError(t, err)
+
+		fixture.opts.quiet = true
+		err = appList(ctx, client, fixture.opts)
+		require.NoError(t, err)
+		assert.Equal(t, fixture.ids, out.String())
+	}
+}
+
+func TestAppListColumns(t *testing.T) {
+	var deploymentCalls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch url := r.URL.String(); url {
+		case "/service/marathon/v2/apps":
+			apps := m.Applications{
+				Apps: []m.Application{
+					{ID: "/b", Labels: &map[string]string{"team": "web", "HAPROXY_GROUP": "external"}, TasksRunning: 1, Instances: intPointer(1)},
+					{ID: "/a", TasksRunning: 0, Instances: intPointer(2)},
+				},
+			}
+			json.NewEncoder(w).Encode(apps)
+		case "/service/marathon/v2/deployments":
+			deploymentCalls++
+			json.NewEncoder(w).Encode([]m.Deployment{})
+		case "/service/marathon/v2/queue":
+			json.NewEncoder(w).Encode(m.Queue{})
+		}
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appList(ctx, client, appListOptions{columns: []string{"id", "tasks", "label:team"}, sortBy: "label:team"})
+	require.NoError(t, err)
+	expected := "  ID  TASKS  LABEL:TEAM  \n" +
+		"  /a  0/2    ---         \n" +
+		"  /b  1/1    web         \n"
+	assert.Equal(t, expected, out.String())
+
+	out.Reset()
+	err = appList(ctx, client, appListOptions{columns: []string{"ID", "Label:HAPROXY_GROUP"}, sortBy: "LABEL:HAPROXY_GROUP"})
+	require.NoError(t, err)
+	expected = "  ID  LABEL:HAPROXY GROUP  \n" +
+		"  /a  ---                  \n" +
+		"  /b  external             \n"
+	assert.Equal(t, expected, out.String())
+
+	deploymentCalls = 0
+	err = appList(ctx, client, appListOptions{quiet: true, sortBy: "Deployment"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, deploymentCalls)
+
+	err = appList(ctx, client, appListOptions{columns: []string{"id", "size"}})
+	assert.EqualError(t, err, "unknown column 'size', expected one of id, mem, cpus, tasks, health, deployment, waiting, container, cmd, role or label:<key>")
+}
+
 // floatPointer returns a pointer to given float, helpful since Go doesn't
 // allow declarations of a constant float pointer
 func floatPointer(f float64) *float64 {
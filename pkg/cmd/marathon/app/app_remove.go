@@ -11,6 +11,7 @@ import (
 
 func newCmdMarathonAppRemove(ctx api.Context) *cobra.Command {
 	var force bool
+	var waitOpts marathon.WaitOptions
 
 	cmd := &cobra.Command{
 		Use:   "remove",
@@ -22,19 +23,29 @@ func newCmdMarathonAppRemove(ctx api.Context) *cobra.Command {
 				return err
 			}
 
-			return marathonAppRemove(*client, args[0], force)
+			return marathonAppRemove(ctx, *client, args[0], force, waitOpts)
 		},
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Disable checks in Marathon during updates.")
+	marathon.AddWaitFlags(cmd, &waitOpts)
 
 	return cmd
 }
 
-func marathonAppRemove(client marathon.Client, appID string, force bool) error {
-	_, err := client.API.DeleteApplication(appID, force)
+func marathonAppRemove(ctx api.Context, client marathon.Client, appID string, force bool, waitOpts marathon.WaitOptions) error {
+	events, unsubscribe, err := client.SubscribeDeploymentEvents(waitOpts)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	deploymentID, err := client.API.DeleteApplication(appID, force)
 	if apiErr, ok := err.(*goMarathon.APIError); ok && apiErr.ErrCode == goMarathon.ErrCodeNotFound {
 		return fmt.Errorf(`app '/%s' does not exist`, appID)
 	}
-	return err
+	if err != nil || !waitOpts.Wait {
+		return err
+	}
+	return client.WaitForDeployment(ctx.Out(), events, deploymentID.DeploymentID, waitOpts.Timeout)
 }
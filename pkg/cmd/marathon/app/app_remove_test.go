@@ -22,7 +22,7 @@ func TestMarathonRemove(t *testing.T) {
 	client, err := marathon.NewClient(ctx)
 	assert.NoError(t, err)
 
-	err = marathonAppRemove(*client, "app-test", false)
+	err = marathonAppRemove(ctx, *client, "app-test", false, marathon.WaitOptions{})
 	assert.NoError(t, err)
 }
 
@@ -39,7 +39,7 @@ func TestMarathonRemoveForce(t *testing.T) {
 	client, err := marathon.NewClient(ctx)
 	assert.NoError(t, err)
 
-	err = marathonAppRemove(*client, "app-test", true)
+	err = marathonAppRemove(ctx, *client, "app-test", true, marathon.WaitOptions{})
 	assert.NoError(t, err)
 }
 
@@ -56,7 +56,7 @@ func TestMarathonRemoveMissingApp(t *testing.T) {
 	client, err := marathon.NewClient(ctx)
 	assert.NoError(t, err)
 
-	err = marathonAppRemove(*client, "app-test", false)
+	err = marathonAppRemove(ctx, *client, "app-test", false, marathon.WaitOptions{})
 	assert.EqualError(t, err, `app '/app-test' does not exist`)
 }
 
@@ -74,6 +74,6 @@ func TestMarathonRemoveOtherError(t *testing.T) {
 	client, err := marathon.NewClient(ctx)
 	assert.NoError(t, err)
 
-	err = marathonAppRemove(*client, "app-test", false)
+	err = marathonAppRemove(ctx, *client, "app-test", false, marathon.WaitOptions{})
 	assert.EqualError(t, err, "Marathon API error: Something bad happened")
 }
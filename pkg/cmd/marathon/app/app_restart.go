@@ -11,6 +11,7 @@ import (
 
 func newCmdMarathonAppRestart(ctx api.Context) *cobra.Command {
 	var force bool
+	var waitOpts marathon.WaitOptions
 
 	cmd := &cobra.Command{
 		Use:   "restart",
@@ -22,6 +23,12 @@ func newCmdMarathonAppRestart(ctx api.Context) *cobra.Command {
 				return err
 			}
 
+			events, unsubscribe, err := client.SubscribeDeploymentEvents(waitOpts)
+			if err != nil {
+				return err
+			}
+			defer unsubscribe()
+
 			deploymentID, err := marathonAppRestart(*client, args[0], force)
 			if apiErr, ok := err.(*goMarathon.APIError); ok && apiErr.ErrCode == goMarathon.ErrCodeNotFound {
 				return fmt.Errorf(`app '/%s' does not exist`, args[0])
@@ -31,11 +38,15 @@ func newCmdMarathonAppRestart(ctx api.Context) *cobra.Command {
 			}
 
 			fmt.Fprintf(ctx.Out(), "Created deployment %s\n", deploymentID.DeploymentID)
-			return nil
+			if !waitOpts.Wait {
+				return nil
+			}
+			return waitForApp(ctx, client, events, deploymentID.DeploymentID, args[0], waitOpts.Timeout)
 		},
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Disable checks in Marathon during updates.")
+	addWaitFlags(cmd, &waitOpts)
 
 	return cmd
 }
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/spf13/cobra"
+)
+
+type appRollbackOptions struct {
+	toVersion string
+	steps     int
+	force     bool
+	yes       bool
+	waitOpts  marathon.WaitOptions
+}
+
+func newCmdMarathonAppRollback(ctx api.Context) *cobra.Command {
+	var opts appRollbackOptions
+
+	cmd := &cobra.Command{
+		Use:   "rollback <app-id>",
+		Short: "Roll back an application to a previous version.",
+		Long: `Roll back an application to a previous version.
+
+By default the application is rolled back to the version preceding the
+currently deployed one. The differences with the current definition are
+printed and a confirmation is asked for before the rollback is deployed.`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.toVersion != "" && cmd.Flags().Changed("steps") {
+				return fmt.Errorf("the flags 'to-version' and 'steps' cannot be used together")
+			}
+			if opts.steps <= 0 {
+				return fmt.Errorf("steps must be a positive number")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			return appRollback(ctx, client, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.toVersion, "to-version", "", appVersionDescription)
+	cmd.Flags().IntVar(&opts.steps, "steps", 1, "Number of versions to go back from the currently deployed one.")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Disable checks in Marathon during updates.")
+	cmd.Flags().BoolVar(&opts.yes, "yes", false, "Disable interactive mode and assume “yes” is the answer to all prompts")
+	addWaitFlags(cmd, &opts.waitOpts)
+
+	return cmd
+}
+
+func appRollback(ctx api.Context, client *marathon.Client, appID string, opts appRollbackOptions) error {
+	version := opts.toVersion
+	if version == "" {
+		version = strconv.Itoa(-opts.steps)
+	}
+	targetVersion, err := calculateVersion(client, appID, version)
+	if err != nil {
+		return err
+	}
+
+	current, err := client.RawApplication(appID, "")
+	if err != nil {
+		return err
+	}
+	target, err := client.RawApplication(appID, targetVersion)
+	if err != nil {
+		return err
+	}
+
+	changes := marathon.Diff(marathon.StripServerFields(current), marathon.StripServerFields(target))
+	if len(changes) == 0 {
+		fmt.Fprintf(ctx.Out(), "Version %s of app '%s' is identical to the current one, nothing to roll back.\n",
+			targetVersion, marathon.NormalizeAppID(appID))
+		return nil
+	}
+
+	fmt.Fprintf(ctx.Out(), "Rolling back app '%s' from version %v to version %s:\n", marathon.NormalizeAppID(appID), current["version"], targetVersion)
+	marathon.PrintDiff(ctx.Out(), changes, marathon.IsColored(ctx.Out()))
+
+	if !opts.yes {
+		err := ctx.Prompt().Confirm("Do you want to continue? [yes/no] ", "no")
+		if err != nil {
+			return err
+		}
+	}
+
+	events, unsubscribe, err := client.SubscribeDeploymentEvents(opts.waitOpts)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	deploymentID, err := client.RollbackApp(appID, targetVersion, opts.force)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.Out(), "Created deployment %s\n", deploymentID)
+
+	if !opts.waitOpts.Wait {
+		return nil
+	}
+	return waitForApp(ctx, client, events, deploymentID, appID, opts.waitOpts.Timeout)
+}
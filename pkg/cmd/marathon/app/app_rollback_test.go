@@ -0,0 +1,117 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rollbackDeploymentID = "5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43"
+
+func rollbackServer(t *testing.T, rolledBack *bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/service/marathon/v2/apps/test":
+			if r.Method == http.MethodPut {
+				var body map[string]string
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, map[string]string{"version": "2020-01-01T00:00:00.000Z"}, body)
+				*rolledBack = true
+				fmt.Fprintf(w, `{"deploymentId":"%s","version":"2020-01-04T00:00:00.000Z"}`, rollbackDeploymentID)
+				return
+			}
+			w.Write([]byte(`{"app":{"id":"/test","cmd":"sleep 300","instances":1,"version":"2020-01-03T00:00:00.000Z"}}`))
+		case "/service/marathon/v2/apps/test/versions":
+			w.Write([]byte(`{"versions":["2020-01-03T00:00:00.000Z","2020-01-02T00:00:00.000Z","2020-01-01T00:00:00.000Z"]}`))
+		case "/service/marathon/v2/apps/test/versions/2020-01-02T00:00:00.000Z":
+			w.Write([]byte(`{"id":"/test","cmd":"sleep 300","instances":1,"version":"2020-01-02T00:00:00.000Z"}`))
+		case "/service/marathon/v2/apps/test/versions/2020-01-01T00:00:00.000Z":
+			w.Write([]byte(`{"id":"/test","cmd":"sleep 100","instances":1,"version":"2020-01-01T00:00:00.000Z"}`))
+		case "/service/marathon/v2/apps":
+			w.Write([]byte(`{"apps":[{"id":"/test","instances":1,"tasksRunning":1}]}`))
+		case "/service/marathon/v2/deployments":
+			w.Write([]byte(`[]`))
+		case "/service/marathon/v2/events":
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, "event: deployment_success\ndata: {\"eventType\":\"deployment_success\",\"id\":\"%s\"}\n\n", rollbackDeploymentID)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		default:
+			t.Fatalf("unexpected call to endpoint %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestAppRollback(t *testing.T) {
+	var rolledBack bool
+	ts := rollbackServer(t, &rolledBack)
+	defer ts.Close()
+
+	ctx, out := newContextWithInput(ts, "yes\n")
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appRollback(ctx, client, "test", appRollbackOptions{steps: 2})
+	require.NoError(t, err)
+	assert.True(t, rolledBack)
+
+	expected := "Rolling back app '/test' from version 2020-01-03T00:00:00.000Z to version 2020-01-01T00:00:00.000Z:\n" +
+		"~ /cmd: \"sleep 300\" -> \"sleep 100\"\n" +
+		"Do you want to continue? [yes/no] " +
+		"Created deployment " + rollbackDeploymentID + "\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestAppRollbackNotConfirmed(t *testing.T) {
+	var rolledBack bool
+	ts := rollbackServer(t, &rolledBack)
+	defer ts.Close()
+
+	ctx, _ := newContextWithInput(ts, "no\n")
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appRollback(ctx, client, "test", appRollbackOptions{toVersion: "2020-01-01T00:00:00.000Z"})
+	assert.EqualError(t, err, "couldn't get confirmation")
+	assert.False(t, rolledBack)
+}
+
+func TestAppRollbackIdenticalVersion(t *testing.T) {
+	var rolledBack bool
+	ts := rollbackServer(t, &rolledBack)
+	defer ts.Close()
+
+	ctx, out := newContextWithInput(ts, "")
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appRollback(ctx, client, "test", appRollbackOptions{steps: 1})
+	require.NoError(t, err)
+	assert.False(t, rolledBack)
+	assert.Equal(t, "Version 2020-01-02T00:00:00.000Z of app '/test' is identical to the current one, nothing to roll back.\n", out.String())
+}
+
+func TestAppRollbackWait(t *testing.T) {
+	var rolledBack bool
+	ts := rollbackServer(t, &rolledBack)
+	defer ts.Close()
+
+	ctx, out := newContextWithInput(ts, "")
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appRollback(ctx, client, "test", appRollbackOptions{toVersion: "-2", yes: true, waitOpts: marathon.WaitOptions{Wait: true}})
+	require.NoError(t, err)
+	assert.True(t, rolledBack)
+	assert.Contains(t, out.String(), "Created deployment "+rollbackDeploymentID+"\n"+
+		"Waiting for deployment "+rollbackDeploymentID+" to finish\n"+
+		"Deployment "+rollbackDeploymentID+" succeeded\n"+
+		"Waiting for app /test to have 1 healthy instance(s)\n")
+}
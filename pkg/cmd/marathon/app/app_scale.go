@@ -0,0 +1,98 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	goMarathon "github.com/gambol99/go-marathon"
+	"github.com/spf13/cobra"
+)
+
+func newCmdMarathonAppScale(ctx api.Context) *cobra.Command {
+	var force bool
+	var waitOpts marathon.WaitOptions
+
+	cmd := &cobra.Command{
+		Use:   "scale <app-id> <instances>",
+		Short: "Scale an application.",
+		Long: `Scale an application.
+
+The number of instances can be absolute, e.g. 5, or relative to the current
+number of instances, e.g. +2 or -2. As a negative number would be read as a
+flag, a decrease must be given after --, e.g.:
+
+  dcos marathon app scale /web -- -2`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			return appScale(ctx, client, args[0], args[1], force, waitOpts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Disable checks in Marathon during updates.")
+	addWaitFlags(cmd, &waitOpts)
+
+	return cmd
+}
+
+func appScale(ctx api.Context, client *marathon.Client, appID string, instancesArg string, force bool, waitOpts marathon.WaitOptions) error {
+	appID = marathon.NormalizeAppID(appID)
+
+	instances, err := strconv.Atoi(instancesArg)
+	if err != nil {
+		return fmt.Errorf("invalid number of instances '%s', expected n, +n or -n", instancesArg)
+	}
+
+	app, err := client.API.Application(appID)
+	if err != nil {
+		if apiErr, ok := err.(*goMarathon.APIError); ok && apiErr.ErrCode == goMarathon.ErrCodeNotFound {
+			return fmt.Errorf(`app '%s' does not exist`, appID)
+		}
+		return err
+	}
+
+	current := 0
+	if app.Instances != nil {
+		current = *app.Instances
+	}
+	if strings.HasPrefix(instancesArg, "+") || strings.HasPrefix(instancesArg, "-") {
+		instances += current
+	}
+	if instances < 0 {
+		return fmt.Errorf("cannot scale app '%s' to %d instances", appID, instances)
+	}
+	if instances == current {
+		fmt.Fprintf(ctx.Out(), "App %s already has %d instance(s)\n", appID, current)
+		return nil
+	}
+
+	events, unsubscribe, err := client.SubscribeDeploymentEvents(waitOpts)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	deploymentID, err := client.API.ScaleApplicationInstances(appID, instances, force)
+	if err != nil {
+		if apiErr, ok := err.(*goMarathon.APIError); ok {
+			switch apiErr.ErrCode {
+			case goMarathon.ErrCodeAppLocked, goMarathon.ErrCodeDuplicateID:
+				return errors.New("changes blocked: deployment already in progress for app")
+			}
+		}
+		return err
+	}
+
+	fmt.Fprintf(ctx.Out(), "Created deployment %s to scale app %s from %d to %d instance(s)\n", deploymentID.DeploymentID, appID, current, instances)
+	if !waitOpts.Wait {
+		return nil
+	}
+	return waitForApp(ctx, client, events, deploymentID.DeploymentID, appID, waitOpts.Timeout)
+}
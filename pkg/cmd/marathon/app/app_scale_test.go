@@ -0,0 +1,110 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const scaleDeploymentID = "5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43"
+
+func TestAppScale(t *testing.T) {
+	tests := []struct {
+		instances string
+		expected  int
+		out       string
+		err       string
+	}{
+		{instances: "5", expected: 5, out: "Created deployment " + scaleDeploymentID + " to scale app /web from 3 to 5 instance(s)\n"},
+		{instances: "+2", expected: 5, out: "Created deployment " + scaleDeploymentID + " to scale app /web from 3 to 5 instance(s)\n"},
+		{instances: "-2", expected: 1, out: "Created deployment " + scaleDeploymentID + " to scale app /web from 3 to 1 instance(s)\n"},
+		{instances: "3", out: "App /web already has 3 instance(s)\n"},
+		{instances: "-4", err: "cannot scale app '/web' to -1 instances"},
+		{instances: "many", err: "invalid number of instances 'many', expected n, +n or -n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.instances, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case "GET":
+					assert.Equal(t, "/service/marathon/v2/apps/web", r.URL.Path)
+					w.Write([]byte(`{"app":{"id":"/web","instances":3}}`))
+				case "PUT":
+					assert.Equal(t, "/service/marathon/v2/apps/web", r.URL.Path)
+					var body map[string]interface{}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, float64(tt.expected), body["instances"])
+					fmt.Fprintf(w, `{"deploymentId":"%s","version":"2020-01-01T00:00:00.000Z"}`, scaleDeploymentID)
+				default:
+					t.Fatalf("unexpected %s request on %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer ts.Close()
+
+			ctx, out := newContext(ts)
+			client, err := marathon.NewClient(ctx)
+			require.NoError(t, err)
+
+			err = appScale(ctx, client, "web", tt.instances, false, marathon.WaitOptions{})
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.out, out.String())
+		})
+	}
+}
+
+func TestAppScaleWait(t *testing.T) {
+	healthPollInterval = time.Millisecond
+
+	deployed := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/service/marathon/v2/events":
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-deployed
+			fmt.Fprintf(w, "event: deployment_success\ndata: {\"eventType\":\"deployment_success\",\"id\":\"%s\"}\n\n", scaleDeploymentID)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		case r.URL.Path == "/service/marathon/v2/deployments":
+			w.Write([]byte(`[]`))
+		case r.URL.Path == "/service/marathon/v2/apps" && r.Method == "GET":
+			w.Write([]byte(`{"apps":[{"id":"/web","instances":5,"tasksRunning":5,"tasksHealthy":4,"healthChecks":[{"protocol":"HTTP"}]}]}`))
+		case r.URL.Path == "/service/marathon/v2/apps/web" && r.Method == "GET":
+			select {
+			case <-deployed:
+				w.Write([]byte(`{"app":{"id":"/web","instances":5}}`))
+			default:
+				w.Write([]byte(`{"app":{"id":"/web","instances":3}}`))
+			}
+		case r.URL.Path == "/service/marathon/v2/apps/web" && r.Method == "PUT":
+			close(deployed)
+			fmt.Fprintf(w, `{"deploymentId":"%s","version":"2020-01-01T00:00:00.000Z"}`, scaleDeploymentID)
+		default:
+			t.Fatalf("unexpected %s request on %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appScale(ctx, client, "/web", "+2", false, marathon.WaitOptions{Wait: true, Timeout: 100 * time.Millisecond})
+	assert.EqualError(t, err, "timeout waiting for app '/web' to have 5 healthy instance(s)")
+	require.IsType(t, &marathon.WaitError{}, err)
+	assert.Equal(t, marathon.ExitCodeTimeout, err.(*marathon.WaitError).ExitCode())
+	assert.Contains(t, out.String(), "Deployment "+scaleDeploymentID+" succeeded\n")
+}
@@ -13,6 +13,7 @@ import (
 func newCmdMarathonAppStart(ctx api.Context) *cobra.Command {
 	var force bool
 	var instances int
+	var waitOpts marathon.WaitOptions
 
 	cmd := &cobra.Command{
 		Use:   "start",
@@ -37,17 +38,27 @@ func newCmdMarathonAppStart(ctx api.Context) *cobra.Command {
 				return err
 			}
 
+			events, unsubscribe, err := client.SubscribeDeploymentEvents(waitOpts)
+			if err != nil {
+				return err
+			}
+			defer unsubscribe()
+
 			deploymentID, err := marathonAppStart(*client, args[0], instances, force)
 			if err != nil {
 				return err
 			}
 
 			fmt.Fprintf(ctx.Out(), "Created deployment %s\n", deploymentID.DeploymentID)
-			return nil
+			if !waitOpts.Wait {
+				return nil
+			}
+			return waitForApp(ctx, client, events, deploymentID.DeploymentID, args[0], waitOpts.Timeout)
 		},
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Disable checks in Marathon during updates.")
+	addWaitFlags(cmd, &waitOpts)
 
 	return cmd
 }
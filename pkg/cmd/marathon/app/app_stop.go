@@ -12,6 +12,7 @@ import (
 
 func newCmdMarathonAppStop(ctx api.Context) *cobra.Command {
 	var force bool
+	var waitOpts marathon.WaitOptions
 
 	cmd := &cobra.Command{
 		Use:   "stop",
@@ -20,16 +21,17 @@ func newCmdMarathonAppStop(ctx api.Context) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			appID := marathon.NormalizeAppID(args[0])
 
-			return appStop(ctx, appID, force)
+			return appStop(ctx, appID, force, waitOpts)
 		},
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Disable checks in Marathon during updates.")
+	addWaitFlags(cmd, &waitOpts)
 
 	return cmd
 }
 
-func appStop(ctx api.Context, appID string, force bool) error {
+func appStop(ctx api.Context, appID string, force bool, waitOpts marathon.WaitOptions) error {
 	client, err := marathon.NewClient(ctx)
 	if err != nil {
 		return err
@@ -46,6 +48,12 @@ func appStop(ctx api.Context, appID string, force bool) error {
 		return fmt.Errorf("app '%s' already stopped: %d instances", appID, *description.Instances)
 	}
 
+	events, unsubscribe, err := client.SubscribeDeploymentEvents(waitOpts)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
 	deploymentID, err := client.API.ScaleApplicationInstances(appID, 0, force)
 	if err != nil {
 		if apiErr, ok := err.(*goMarathon.APIError); ok {
@@ -58,6 +66,8 @@ func appStop(ctx api.Context, appID string, force bool) error {
 	}
 
 	fmt.Fprintf(ctx.Out(), "Created deployment %s\n", deploymentID.DeploymentID)
-
-	return nil
+	if !waitOpts.Wait {
+		return nil
+	}
+	return waitForApp(ctx, client, events, deploymentID.DeploymentID, appID, waitOpts.Timeout)
 }
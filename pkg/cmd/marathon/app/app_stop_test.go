@@ -6,10 +6,12 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/dcos/dcos-cli/pkg/config"
 	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	goMarathon "github.com/gambol99/go-marathon"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -86,7 +88,7 @@ func TestAppStopTable(t *testing.T) {
 			ts := router(tt.getFunc, tt.putFunc)
 			ctx, out := newContext(ts)
 
-			err := appStop(ctx, "/test", tt.force)
+			err := appStop(ctx, "/test", tt.force, marathon.WaitOptions{})
 
 			require.Equal(t, tt.err, err)
 			assert.Equal(t, tt.out, out.String())
@@ -132,3 +134,15 @@ func newContext(ts *httptest.Server) (*mock.Context, *bytes.Buffer) {
 	ctx.SetCluster(cluster)
 	return ctx, out
 }
+
+func newContextWithInput(ts *httptest.Server, input string) (*mock.Context, *bytes.Buffer) {
+	out := new(bytes.Buffer)
+	env := mock.NewEnvironment()
+	env.Out = out
+	env.Input = strings.NewReader(input)
+	ctx := mock.NewContext(env)
+	cluster := config.NewCluster(nil)
+	cluster.SetURL(ts.URL)
+	ctx.SetCluster(cluster)
+	return ctx, out
+}
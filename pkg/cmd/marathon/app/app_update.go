@@ -1,23 +1,171 @@
 package app
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
+type appUpdateOptions struct {
+	properties []string
+	appFile    string
+	patchFile  string
+	replace    bool
+	force      bool
+	vars       []string
+	varFile    string
+	waitOpts   marathon.WaitOptions
+}
+
 func newCmdMarathonAppUpdate(ctx api.Context) *cobra.Command {
-	var force bool
+	var opts appUpdateOptions
 
 	cmd := &cobra.Command{
-		Use:   "update",
+		Use:   "update <app-id> [<properties>...]",
 		Short: "Update an application.",
+		Long: `Update an application.
+
+The new definition is built from the current one, changed by the given
+properties in the form key=value. A definition can be given instead with
+--file, and an RFC 7386 JSON merge patch with --patch. Without any
+property, file or patch, the definition is read from stdin.
+
+The top-level fields of a definition given with --file or on stdin replace
+the ones of the current definition, the other fields are kept. With
+--replace, the definition replaces the current one and the fields it
+doesn't have are removed.
+
+The differences with the current definition are printed before the update
+is applied.
+
+When --var or --var-file is given, the full definition and the patch are
+rendered as Go templates, see 'dcos marathon app add --help'.`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			opts.properties = args[1:]
+			return appUpdate(ctx, client, args[0], opts)
 		},
 	}
 
-	cmd.Flags().BoolVar(&force, "force", false, "Disable checks in Marathon during updates.")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Disable checks in Marathon during updates.")
+	cmd.Flags().StringVar(&opts.appFile, "file", "", "Path or URL to the app definition to deploy.")
+	cmd.Flags().BoolVar(&opts.replace, "replace", false, "Replace the app definition instead of updating its fields.")
+	cmd.Flags().StringVar(&opts.patchFile, "patch", "", "Path or URL to a JSON merge patch to apply to the app definition.")
+	cmd.Flags().StringArrayVar(&opts.vars, "var", nil, "Template variable in the form key=value, can be repeated.")
+	cmd.Flags().StringVar(&opts.varFile, "var-file", "", "Path to a YAML or JSON file of template variables.")
+	addWaitFlags(cmd, &opts.waitOpts)
 
 	return cmd
 }
+
+func appUpdate(ctx api.Context, client *marathon.Client, appID string, opts appUpdateOptions) error {
+	current, err := client.RawApplication(appID, "")
+	if err != nil {
+		return err
+	}
+	current = marathon.StripServerFields(current)
+
+	desired, err := desiredAppDefinition(ctx, current, opts)
+	if err != nil {
+		return err
+	}
+
+	changes := marathon.Diff(current, desired)
+	if len(changes) == 0 {
+		fmt.Fprintln(ctx.Out(), "No changes to apply.")
+		return nil
+	}
+	marathon.PrintDiff(ctx.Out(), changes, marathon.IsColored(ctx.Out()))
+
+	events, unsubscribe, err := client.SubscribeDeploymentEvents(opts.waitOpts)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	deploymentID, err := client.UpdateApp(appID, desired, opts.force)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ctx.Out(), "Created deployment %s\n", deploymentID)
+	if !opts.waitOpts.Wait {
+		return nil
+	}
+	return waitForApp(ctx, client, events, deploymentID, appID, opts.waitOpts.Timeout)
+}
+
+// desiredAppDefinition builds the new definition of an app from its current one and the update options.
+func desiredAppDefinition(ctx api.Context, current map[string]interface{}, opts appUpdateOptions) (map[string]interface{}, error) {
+	vars, err := marathon.TemplateVars(opts.varFile, opts.vars)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := current
+
+	if opts.appFile != "" || (opts.patchFile == "" && len(opts.properties) == 0) {
+		definition, err := loadDefinition(ctx, opts.appFile, vars)
+		if err != nil {
+			return nil, err
+		}
+		definition = marathon.StripServerFields(definition)
+		if opts.replace {
+			desired = definition
+		} else {
+			desired = make(map[string]interface{}, len(current))
+			for key, value := range current {
+				desired[key] = value
+			}
+		}
+		for key, value := range definition {
+			desired[key] = value
+		}
+		if _, ok := desired["id"]; !ok {
+			desired["id"] = current["id"]
+		}
+	}
+
+	if opts.patchFile != "" {
+		patch, err := loadDefinition(ctx, opts.patchFile, vars)
+		if err != nil {
+			return nil, err
+		}
+		desired = marathon.MergePatch(desired, patch).(map[string]interface{})
+	}
+
+	if len(opts.properties) > 0 {
+		properties, err := marathon.ParseProperties(opts.properties)
+		if err != nil {
+			return nil, err
+		}
+		desired = marathon.StripServerFields(desired)
+		for key, value := range properties {
+			desired[key] = value
+		}
+	}
+
+	if marathon.NormalizeAppID(fmt.Sprint(desired["id"])) != marathon.NormalizeAppID(fmt.Sprint(current["id"])) {
+		return nil, fmt.Errorf("app ID '%v' doesn't match the app being updated '%v'", desired["id"], current["id"])
+	}
+	return desired, nil
+}
+
+// loadDefinition loads a definition through marathon.LoadDefinition and turns its errors into user-facing ones.
+func loadDefinition(ctx api.Context, location string, vars map[string]interface{}) (map[string]interface{}, error) {
+	definition, err := marathon.LoadDefinition(ctx, location, vars)
+	if err == marathon.ErrCannotReadAppDefinition {
+		return nil, fmt.Errorf("can't read from resource: %s. Please check that it exists", location)
+	} else if syntaxError, ok := err.(*json.SyntaxError); ok {
+		return nil, fmt.Errorf("error loading JSON: %s", syntaxError.Error())
+	}
+	return definition, err
+}
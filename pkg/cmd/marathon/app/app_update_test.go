@@ -0,0 +1,190 @@
+package app
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const currentApp = `{"app":{"id":"/test","cmd":"sleep 100","cpus":0.1,"mem":32,"instances":1,
+"env":{"FOO":"bar"},"version":"2020-01-01T00:00:00.000Z","tasksRunning":1,"deployments":[]}}`
+
+func updateServer(t *testing.T, expected map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/service/marathon/v2/apps/test", r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(currentApp))
+		case http.MethodPut:
+			assert.Equal(t, "false", r.URL.Query().Get("partialUpdate"))
+
+			var app map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&app))
+			assert.Equal(t, expected, app)
+			w.Write([]byte(`{"deploymentId":"5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43","version":"2020-01-02T00:00:00.000Z"}`))
+		default:
+			t.Fatalf("unexpected http verb %s", r.Method)
+		}
+	}))
+}
+
+func TestAppUpdateProperties(t *testing.T) {
+	ts := updateServer(t, map[string]interface{}{
+		"id":        "/test",
+		"cmd":       "sleep 100",
+		"cpus":      0.5,
+		"mem":       float64(32),
+		"instances": float64(1),
+		"env":       map[string]interface{}{"FOO": "bar"},
+		"labels":    map[string]interface{}{"team": "infra"},
+	})
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appUpdate(ctx, client, "test", appUpdateOptions{properties: []string{"cpus=0.5", `labels={"team":"infra"}`}})
+	require.NoError(t, err)
+
+	expected := "~ /cpus: 0.1 -> 0.5\n" +
+		"+ /labels: {\"team\":\"infra\"}\n" +
+		"Created deployment 5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestAppUpdateMergePatch(t *testing.T) {
+	ts := updateServer(t, map[string]interface{}{
+		"id":        "/test",
+		"cmd":       "sleep 100",
+		"cpus":      0.1,
+		"mem":       float64(32),
+		"instances": float64(3),
+		"env":       map[string]interface{}{"BAZ": "qux"},
+	})
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "app-update")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	patchFile := filepath.Join(dir, "patch.json")
+	err = ioutil.WriteFile(patchFile, []byte(`{"instances":3,"env":{"FOO":null,"BAZ":"qux"}}`), 0600)
+	require.NoError(t, err)
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appUpdate(ctx, client, "/test", appUpdateOptions{patchFile: patchFile})
+	require.NoError(t, err)
+
+	expected := "+ /env/BAZ: \"qux\"\n" +
+		"- /env/FOO: \"bar\"\n" +
+		"~ /instances: 1 -> 3\n" +
+		"Created deployment 5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestAppUpdateStdin(t *testing.T) {
+	ts := updateServer(t, map[string]interface{}{
+		"id":        "/test",
+		"cmd":       "sleep 200",
+		"cpus":      0.1,
+		"mem":       float64(32),
+		"instances": float64(1),
+		"env":       map[string]interface{}{"FOO": "bar"},
+	})
+	defer ts.Close()
+
+	ctx, out := newContextWithInput(ts, `{"cmd":"sleep 200"}`)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appUpdate(ctx, client, "test", appUpdateOptions{})
+	require.NoError(t, err)
+
+	expected := "~ /cmd: \"sleep 100\" -> \"sleep 200\"\n" +
+		"Created deployment 5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestAppUpdateReplace(t *testing.T) {
+	ts := updateServer(t, map[string]interface{}{
+		"id":   "/test",
+		"cmd":  "sleep 200",
+		"cpus": 0.1,
+		"mem":  float64(32),
+	})
+	defer ts.Close()
+
+	ctx, out := newContextWithInput(ts, `{"cmd":"sleep 200","cpus":0.1,"mem":32}`)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appUpdate(ctx, client, "test", appUpdateOptions{replace: true})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "~ /cmd: \"sleep 100\" -> \"sleep 200\"\n")
+	assert.Contains(t, out.String(), "- /instances: 1\n")
+}
+
+func TestAppUpdateNoChanges(t *testing.T) {
+	ts := updateServer(t, nil)
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appUpdate(ctx, client, "test", appUpdateOptions{properties: []string{"cpus=0.1"}})
+	require.NoError(t, err)
+	assert.Equal(t, "No changes to apply.\n", out.String())
+}
+
+func TestAppUpdateMismatchingID(t *testing.T) {
+	ts := updateServer(t, nil)
+	defer ts.Close()
+
+	ctx, _ := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appUpdate(ctx, client, "test", appUpdateOptions{properties: []string{"id=/other"}})
+	assert.EqualError(t, err, "app ID '/other' doesn't match the app being updated '/test'")
+}
+
+func TestAppUpdateTemplatedPatch(t *testing.T) {
+	ts := updateServer(t, map[string]interface{}{
+		"id":        "/test",
+		"cmd":       "sleep 100",
+		"cpus":      0.1,
+		"mem":       float64(32),
+		"instances": float64(5),
+		"env":       map[string]interface{}{"FOO": "bar"},
+	})
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "app-update")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	patchFile := filepath.Join(dir, "patch.json")
+	err = ioutil.WriteFile(patchFile, []byte(`{"instances":{{ default "instances" 1 }}}`), 0600)
+	require.NoError(t, err)
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = appUpdate(ctx, client, "test", appUpdateOptions{patchFile: patchFile, vars: []string{"instances=5"}})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "~ /instances: 1 -> 5\n")
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/spf13/cobra"
+)
+
+func newCmdMarathonAppValidate(ctx api.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [<app-resource>]",
+		Short: "Validate an application definition without deploying it.",
+		Long: `Validate an application definition without deploying it.
+
+The definition is checked locally against the Marathon app schema, no
+cluster is needed. If no resource is given, the definition is read from
+stdin.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return appValidate(ctx, args[0])
+			}
+			return appValidate(ctx, "")
+		},
+	}
+	return cmd
+}
+
+func appValidate(ctx api.Context, appFile string) error {
+	app, err := loadDefinition(ctx, appFile, nil)
+	if err != nil {
+		return err
+	}
+
+	errors := marathon.ValidateApp(app)
+	if len(errors) == 0 {
+		fmt.Fprintln(ctx.Out(), "The app definition is valid.")
+		return nil
+	}
+
+	for _, validationError := range errors {
+		fmt.Fprintln(ctx.Out(), validationError)
+	}
+	return fmt.Errorf("the app definition has %d error(s)", len(errors))
+}
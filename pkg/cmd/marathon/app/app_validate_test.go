@@ -0,0 +1,55 @@
+package app
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppValidate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		definition string
+		output     string
+		err        string
+	}{
+		{
+			name:       "valid",
+			definition: `{"id":"/web","cmd":"sleep 100"}`,
+			output:     "The app definition is valid.\n",
+		},
+		{
+			name:       "invalid",
+			definition: `{"cmd":"sleep 100","args":["sleep"],"instances":-1}`,
+			output: "/args: cmd and args can't be used together\n" +
+				"/id: required field is missing\n" +
+				"/instances: must be greater than or equal to 0\n",
+			err: "the app definition has 3 error(s)",
+		},
+		{
+			name:       "invalid JSON",
+			definition: `{"id":`,
+			err:        "error loading JSON: unexpected end of JSON input",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			env := mock.NewEnvironment()
+			env.Out = out
+			env.Input = strings.NewReader(tc.definition)
+
+			err := appValidate(mock.NewContext(env), "")
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+			assert.Equal(t, tc.output, out.String())
+		})
+	}
+}
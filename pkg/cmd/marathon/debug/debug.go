@@ -14,6 +14,7 @@ func NewCmdMarathonDebug(ctx api.Context) *cobra.Command {
 	cmd.AddCommand(
 		newCmdMarathonDebugDetails(ctx),
 		newCmdMarathonDebugList(ctx),
+		newCmdMarathonDebugSimulate(ctx),
 		newCmdMarathonDebugSummary(ctx),
 	)
 
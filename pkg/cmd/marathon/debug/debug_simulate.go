@@ -0,0 +1,516 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-cli/pkg/cli"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/dcos/dcos-core-cli/pkg/mesos"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func newCmdMarathonDebugSimulate(ctx api.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate <app-resource|app-id>",
+		Short: "Simulate the placement of an application on the cluster agents.",
+		Long: `Simulate the placement of an application on the cluster agents.
+
+The app definition is read from a file or URL, or from Marathon when an
+app ID is given. Its role, constraints and cpus, mem, disk and ports
+requirements are evaluated against the resources currently available on
+each agent, as reported by Mesos, to tell whether all its instances can be
+placed. Resources used by the app's own running tasks are not released in
+the simulation.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			mesosClient, err := mesos.NewClientWithContext(ctx)
+			if err != nil {
+				return err
+			}
+			return marathonDebugSimulate(ctx, client, mesosClient, args[0])
+		},
+	}
+	return cmd
+}
+
+// simulatedApp holds the fields of an app definition which matter for its placement.
+type simulatedApp struct {
+	ID                    string     `json:"id"`
+	Instances             *int       `json:"instances"`
+	CPUs                  *float64   `json:"cpus"`
+	Mem                   *float64   `json:"mem"`
+	Disk                  float64    `json:"disk"`
+	Role                  string     `json:"role"`
+	AcceptedResourceRoles []string   `json:"acceptedResourceRoles"`
+	Constraints           [][]string `json:"constraints"`
+	Ports                 []int      `json:"ports"`
+	RequirePorts          bool       `json:"requirePorts"`
+	PortDefinitions       []struct {
+		Port int `json:"port"`
+	} `json:"portDefinitions"`
+	Container *struct {
+		PortMappings []struct {
+			HostPort *int `json:"hostPort"`
+		} `json:"portMappings"`
+	} `json:"container"`
+	Networks []struct {
+		Mode string `json:"mode"`
+	} `json:"networks"`
+}
+
+// instances returns the number of instances of the app, defaulting to 1 like Marathon.
+func (a *simulatedApp) instances() int {
+	if a.Instances == nil {
+		return 1
+	}
+	return *a.Instances
+}
+
+// resources returns the cpus and mem requested per instance, defaulting to Marathon's defaults.
+func (a *simulatedApp) resources() (float64, float64) {
+	cpus, mem := 1.0, 128.0
+	if a.CPUs != nil {
+		cpus = *a.CPUs
+	}
+	if a.Mem != nil {
+		mem = *a.Mem
+	}
+	return cpus, mem
+}
+
+// hostPorts returns the host ports needed per instance, 0 meaning any port.
+func (a *simulatedApp) hostPorts() []int {
+	hostNetworking := true
+	for _, network := range a.Networks {
+		if network.Mode != "" && network.Mode != "host" {
+			hostNetworking = false
+		}
+	}
+
+	if !hostNetworking {
+		var ports []int
+		if a.Container != nil {
+			for _, mapping := range a.Container.PortMappings {
+				if mapping.HostPort != nil {
+					ports = append(ports, *mapping.HostPort)
+				}
+			}
+		}
+		return ports
+	}
+
+	var ports []int
+	switch {
+	case len(a.PortDefinitions) > 0:
+		for _, definition := range a.PortDefinitions {
+			ports = append(ports, definition.Port)
+		}
+	case a.Ports != nil:
+		ports = append(ports, a.Ports...)
+	default:
+		// Marathon gives one port to apps without any port definition.
+		ports = []int{0}
+	}
+
+	if !a.RequirePorts {
+		// Without requirePorts, Marathon picks any available port.
+		for i := range ports {
+			ports[i] = 0
+		}
+	}
+	return ports
+}
+
+// acceptedRoles returns the roles whose resources the app can use, "*" meaning unreserved resources.
+func (a *simulatedApp) acceptedRoles() []string {
+	if len(a.AcceptedResourceRoles) > 0 {
+		return a.AcceptedResourceRoles
+	}
+	if a.Role == "" || a.Role == "*" {
+		return []string{"*"}
+	}
+	return []string{"*", a.Role}
+}
+
+// agentFit is the result of the evaluation of an app against an agent.
+type agentFit struct {
+	agent mesos.Slave
+	cpus  float64
+	mem   float64
+	disk  float64
+	ports []portRange
+	// constraintError describes the constraint which isn't met by the agent, if any.
+	constraintError string
+	// capacity is the number of instances which fit in the agent resources.
+	capacity int
+}
+
+func marathonDebugSimulate(ctx api.Context, client *marathon.Client, mesosClient *mesos.Client, resource string) error {
+	app, err := loadSimulatedApp(ctx, client, resource)
+	if err != nil {
+		return err
+	}
+
+	state, err := mesosClient.State()
+	if err != nil {
+		return err
+	}
+
+	var fits []agentFit
+	for _, agent := range state.Slaves {
+		if agent.Active {
+			fits = append(fits, evaluateAgent(app, agent))
+		}
+	}
+	sort.Slice(fits, func(i, j int) bool {
+		return fits[i].agent.Hostname < fits[j].agent.Hostname
+	})
+
+	cpus, mem := app.resources()
+	fmt.Fprintf(ctx.Out(), "Simulating the placement of %d instance(s) of %s (cpus: %v, mem: %v, disk: %v, ports: %d) with roles %s\n\n",
+		app.instances(), marathon.NormalizeAppID(app.ID), cpus, mem, app.Disk, len(app.hostPorts()), strings.Join(app.acceptedRoles(), ", "))
+
+	table := cli.NewTable(ctx.Out(), []string{"AGENT", "REGION", "ZONE", "CPUS", "MEM", "DISK", "PORTS", "CONSTRAINTS", "CAPACITY"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	for _, fit := range fits {
+		constraints := "ok"
+		if fit.constraintError != "" {
+			constraints = fit.constraintError
+		}
+		table.Append([]string{
+			fit.agent.Hostname,
+			orNilString(fit.agent.Domain.FaultDomain.Region.Name),
+			orNilString(fit.agent.Domain.FaultDomain.Zone.Name),
+			formatResource(fit.cpus, cpus),
+			formatResource(fit.mem, mem),
+			formatResource(fit.disk, app.Disk),
+			formatPorts(fit.ports, len(app.hostPorts())),
+			constraints,
+			strconv.Itoa(fit.capacity),
+		})
+	}
+	table.Render()
+
+	placed := placeInstances(app, fits)
+	if placed >= app.instances() {
+		fmt.Fprintf(ctx.Out(), "\nAll %d instance(s) can be placed.\n", app.instances())
+		return nil
+	}
+	fmt.Fprintf(ctx.Out(), "\nOnly %d of %d instance(s) can be placed.\n", placed, app.instances())
+	return nil
+}
+
+// loadSimulatedApp loads the app from a file or URL, or from Marathon when resource isn't one.
+func loadSimulatedApp(ctx api.Context, client *marathon.Client, resource string) (*simulatedApp, error) {
+	var definition map[string]interface{}
+	var err error
+
+	u, urlErr := url.Parse(resource)
+	_, statErr := os.Stat(resource)
+	if statErr == nil || (urlErr == nil && (u.Scheme == "http" || u.Scheme == "https")) {
+		definition, err = marathon.LoadDefinition(ctx, resource, nil)
+		if err == marathon.ErrCannotReadAppDefinition {
+			return nil, fmt.Errorf("can't read from resource: %s. Please check that it exists", resource)
+		}
+	} else {
+		definition, err = client.RawApplication(resource, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(definition)
+	if err != nil {
+		return nil, err
+	}
+	var app simulatedApp
+	if err := json.Unmarshal(data, &app); err != nil {
+		return nil, fmt.Errorf("invalid app definition: %s", err)
+	}
+	return &app, nil
+}
+
+// evaluateAgent returns the resources of the agent available to the app and how many instances fit in them.
+func evaluateAgent(app *simulatedApp, agent mesos.Slave) agentFit {
+	fit := agentFit{agent: agent}
+
+	// Resources can be taken from the unreserved ones and the ones reserved for the accepted roles,
+	// without exceeding what isn't used on the agent.
+	var offered mesos.Resources
+	var offeredPorts []portRange
+	for _, role := range app.acceptedRoles() {
+		resources := agent.UnreservedResources
+		if role != "*" {
+			resources = agent.ReservedResources[role]
+		}
+		offered.CPUs += resources.CPUs
+		offered.Mem += resources.Mem
+		offered.Disk += resources.Disk
+		offeredPorts = append(offeredPorts, parsePortRanges(resources.Ports)...)
+	}
+	fit.cpus = math.Max(0, math.Min(offered.CPUs, agent.Resources.CPUs-agent.UsedResources.CPUs))
+	fit.mem = math.Max(0, math.Min(offered.Mem, agent.Resources.Mem-agent.UsedResources.Mem))
+	fit.disk = math.Max(0, math.Min(offered.Disk, agent.Resources.Disk-agent.UsedResources.Disk))
+	fit.ports = subtractPortRanges(offeredPorts, parsePortRanges(agent.UsedResources.Ports))
+
+	for _, constraint := range app.Constraints {
+		if !matchesAgentConstraint(constraint, agent) {
+			fit.constraintError = strings.Join(constraint, " ")
+			return fit
+		}
+	}
+
+	cpus, mem := app.resources()
+	fit.capacity = math.MaxInt32
+	for _, r := range []struct{ available, requested float64 }{{fit.cpus, cpus}, {fit.mem, mem}, {fit.disk, app.Disk}} {
+		if r.requested > 0 {
+			fit.capacity = minInt(fit.capacity, int(math.Floor(r.available/r.requested+1e-9)))
+		}
+	}
+
+	ports := app.hostPorts()
+	if len(ports) > 0 {
+		fit.capacity = minInt(fit.capacity, countPorts(fit.ports)/len(ports))
+		for _, port := range ports {
+			if port != 0 {
+				if !containsPort(fit.ports, port) {
+					fit.capacity = 0
+				}
+				// A fixed host port can only be used by a single instance.
+				fit.capacity = minInt(fit.capacity, 1)
+			}
+		}
+	}
+	return fit
+}
+
+// agentField returns the value of a constraint field for an agent.
+func agentField(field string, agent mesos.Slave) (string, bool) {
+	switch field {
+	case "hostname", "@hostname":
+		return agent.Hostname, true
+	case "@region":
+		region := agent.Domain.FaultDomain.Region.Name
+		return region, region != ""
+	case "@zone":
+		zone := agent.Domain.FaultDomain.Zone.Name
+		return zone, zone != ""
+	}
+
+	value, ok := agent.Attributes[field]
+	if !ok {
+		return "", false
+	}
+	if number, ok := value.(float64); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64), true
+	}
+	return fmt.Sprint(value), true
+}
+
+// matchesAgentConstraint returns whether an agent can run an instance with regards to a constraint.
+// Constraints depending on the other instances (UNIQUE, MAX_PER, GROUP_BY, CLUSTER without a value)
+// are evaluated when placing the instances.
+func matchesAgentConstraint(constraint []string, agent mesos.Slave) bool {
+	if len(constraint) < 2 {
+		return true
+	}
+
+	value, ok := agentField(constraint[0], agent)
+	operator := strings.ToUpper(constraint[1])
+	if len(constraint) < 3 {
+		return operator != "CLUSTER" || ok
+	}
+
+	switch operator {
+	case "LIKE", "UNLIKE":
+		re, err := regexp.Compile("^(?:" + constraint[2] + ")$")
+		if err != nil {
+			return false
+		}
+		if operator == "LIKE" {
+			return ok && re.MatchString(value)
+		}
+		return !ok || !re.MatchString(value)
+	case "IS", "CLUSTER":
+		return ok && value == constraint[2]
+	default:
+		return true
+	}
+}
+
+// placeInstances greedily places the app instances on the agents and returns how many could be placed.
+func placeInstances(app *simulatedApp, fits []agentFit) int {
+	capacities := make([]int, len(fits))
+	for i, fit := range fits {
+		capacities[i] = fit.capacity
+	}
+
+	counts := make(map[string]int)
+	clusterValues := make(map[string]string)
+	placed := 0
+	for placed < app.instances() {
+		best := -1
+		for i, fit := range fits {
+			if capacities[i] <= 0 || !canPlace(app, fit.agent, counts, clusterValues) {
+				continue
+			}
+			if best == -1 || capacities[i] > capacities[best] {
+				best = i
+			}
+		}
+		if best == -1 {
+			break
+		}
+
+		capacities[best]--
+		for _, constraint := range app.Constraints {
+			if len(constraint) < 2 {
+				continue
+			}
+			if value, ok := agentField(constraint[0], fits[best].agent); ok {
+				counts[constraint[0]+"="+value]++
+				if len(constraint) == 2 && strings.ToUpper(constraint[1]) == "CLUSTER" {
+					clusterValues[constraint[0]] = value
+				}
+			}
+		}
+		placed++
+	}
+	return placed
+}
+
+// canPlace returns whether an instance can be placed on the agent given the instances already placed.
+func canPlace(app *simulatedApp, agent mesos.Slave, counts map[string]int, clusterValues map[string]string) bool {
+	for _, constraint := range app.Constraints {
+		if len(constraint) < 2 {
+			continue
+		}
+		value, ok := agentField(constraint[0], agent)
+		count := counts[constraint[0]+"="+value]
+
+		switch strings.ToUpper(constraint[1]) {
+		case "UNIQUE":
+			if !ok || count >= 1 {
+				return false
+			}
+		case "MAX_PER":
+			if len(constraint) < 3 {
+				continue
+			}
+			max, err := strconv.Atoi(constraint[2])
+			if err != nil || !ok || count >= max {
+				return false
+			}
+		case "CLUSTER":
+			if clusterValue, set := clusterValues[constraint[0]]; set && clusterValue != value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// portRange is an inclusive range of ports.
+type portRange struct {
+	begin int
+	end   int
+}
+
+// parsePortRanges parses Mesos port ranges such as "[31000-31005, 31010-32000]".
+func parsePortRanges(ports string) []portRange {
+	var ranges []portRange
+	for _, term := range strings.Split(strings.Trim(ports, "[] "), ",") {
+		bounds := strings.SplitN(strings.TrimSpace(term), "-", 2)
+		if len(bounds) != 2 {
+			continue
+		}
+		begin, err1 := strconv.Atoi(bounds[0])
+		end, err2 := strconv.Atoi(bounds[1])
+		if err1 == nil && err2 == nil && begin <= end {
+			ranges = append(ranges, portRange{begin, end})
+		}
+	}
+	return ranges
+}
+
+// subtractPortRanges returns the ports of ranges which aren't in used.
+func subtractPortRanges(ranges []portRange, used []portRange) []portRange {
+	result := ranges
+	for _, u := range used {
+		var next []portRange
+		for _, r := range result {
+			if u.end < r.begin || u.begin > r.end {
+				next = append(next, r)
+				continue
+			}
+			if r.begin < u.begin {
+				next = append(next, portRange{r.begin, u.begin - 1})
+			}
+			if r.end > u.end {
+				next = append(next, portRange{u.end + 1, r.end})
+			}
+		}
+		result = next
+	}
+	return result
+}
+
+func countPorts(ranges []portRange) int {
+	count := 0
+	for _, r := range ranges {
+		count += r.end - r.begin + 1
+	}
+	return count
+}
+
+func containsPort(ranges []portRange, port int) bool {
+	for _, r := range ranges {
+		if port >= r.begin && port <= r.end {
+			return true
+		}
+	}
+	return false
+}
+
+func formatResource(available float64, requested float64) string {
+	if available < requested {
+		return fmt.Sprintf("%v (needs %v)", available, requested)
+	}
+	return fmt.Sprintf("%v", available)
+}
+
+func formatPorts(ranges []portRange, requested int) string {
+	available := countPorts(ranges)
+	if available < requested {
+		return fmt.Sprintf("%d (needs %d)", available, requested)
+	}
+	return strconv.Itoa(available)
+}
+
+func orNilString(value string) string {
+	if value == "" {
+		return nilString
+	}
+	return value
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,114 @@
+package debug
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/dcos/dcos-core-cli/pkg/mesos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const simulateState = `{"slaves":[
+	{"id":"a1","hostname":"10.0.0.1","active":true,"attributes":{"rack":"r1"},
+	 "domain":{"fault_domain":{"region":{"name":"us-east"},"zone":{"name":"us-east-1a"}}},
+	 "resources":{"cpus":4,"mem":4096,"disk":1000,"ports":"[31000-31010]"},
+	 "used_resources":{"cpus":1,"mem":1024,"disk":0,"ports":"[31000-31000]"},
+	 "unreserved_resources":{"cpus":4,"mem":4096,"disk":1000,"ports":"[31000-31010]"}},
+	{"id":"a2","hostname":"10.0.0.2","active":true,"attributes":{"rack":"r2"},
+	 "resources":{"cpus":1,"mem":4096,"disk":1000,"ports":"[31000-31010]"},
+	 "used_resources":{"cpus":0.5,"mem":0,"disk":0},
+	 "unreserved_resources":{"cpus":1,"mem":4096,"disk":1000,"ports":"[31000-31010]"}},
+	{"id":"a3","hostname":"10.0.0.3","active":false,
+	 "resources":{"cpus":8,"mem":8192,"disk":1000,"ports":"[31000-31010]"},
+	 "unreserved_resources":{"cpus":8,"mem":8192,"disk":1000,"ports":"[31000-31010]"}}
+]}`
+
+func TestMarathonDebugSimulate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/service/marathon/v2/apps/web":
+			w.Write([]byte(`{"app":{"id":"/web","cmd":"sleep 100","instances":4,"cpus":1,"mem":512,"constraints":[["rack","LIKE","r.*"]]}}`))
+		case "/mesos/master/state":
+			w.Write([]byte(simulateState))
+		default:
+			t.Fatalf("unexpected request %s", r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+	mesosClient, err := mesos.NewClientWithContext(ctx)
+	require.NoError(t, err)
+
+	err = marathonDebugSimulate(ctx, client, mesosClient, "/web")
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "10.0.0.1")
+	assert.Contains(t, out.String(), "0.5 (needs 1)")
+	assert.NotContains(t, out.String(), "10.0.0.3")
+	assert.Contains(t, out.String(), "Only 3 of 4 instance(s) can be placed.")
+}
+
+func TestEvaluateAgent(t *testing.T) {
+	agent := mesos.Slave{
+		Hostname:            "10.0.0.1",
+		Attributes:          map[string]interface{}{"rack": "r1"},
+		Resources:           mesos.Resources{CPUs: 4, Mem: 4096, Ports: "[31000-31003]"},
+		UsedResources:       mesos.Resources{CPUs: 1, Ports: "[31001-31001]"},
+		UnreservedResources: mesos.Resources{CPUs: 2, Mem: 4096, Ports: "[31000-31003]"},
+		ReservedResources:   map[string]mesos.Resources{"slave_public": {CPUs: 2, Mem: 0}},
+	}
+	two, one := 2.0, 1.0
+
+	app := &simulatedApp{CPUs: &one}
+	fit := evaluateAgent(app, agent)
+	assert.Equal(t, 2.0, fit.cpus)
+	assert.Equal(t, 3, countPorts(fit.ports))
+	assert.Equal(t, 2, fit.capacity)
+
+	app = &simulatedApp{CPUs: &one, Role: "slave_public"}
+	fit = evaluateAgent(app, agent)
+	assert.Equal(t, 3.0, fit.cpus)
+	assert.Equal(t, 3, fit.capacity)
+
+	app = &simulatedApp{CPUs: &two, Constraints: [][]string{{"rack", "IS", "r2"}}}
+	fit = evaluateAgent(app, agent)
+	assert.Equal(t, "rack IS r2", fit.constraintError)
+	assert.Equal(t, 0, fit.capacity)
+
+	app = &simulatedApp{CPUs: &one, RequirePorts: true, PortDefinitions: []struct {
+		Port int `json:"port"`
+	}{{Port: 31001}}}
+	fit = evaluateAgent(app, agent)
+	assert.Equal(t, 0, fit.capacity)
+}
+
+func TestPlaceInstances(t *testing.T) {
+	agents := []mesos.Slave{
+		{Hostname: "a", Attributes: map[string]interface{}{"rack": "r1"}},
+		{Hostname: "b", Attributes: map[string]interface{}{"rack": "r1"}},
+		{Hostname: "c", Attributes: map[string]interface{}{"rack": "r2"}},
+	}
+	fits := []agentFit{{agent: agents[0], capacity: 5}, {agent: agents[1], capacity: 5}, {agent: agents[2], capacity: 5}}
+	instances := 6
+
+	app := &simulatedApp{Instances: &instances, Constraints: [][]string{{"hostname", "UNIQUE"}}}
+	assert.Equal(t, 3, placeInstances(app, fits))
+
+	app = &simulatedApp{Instances: &instances, Constraints: [][]string{{"rack", "MAX_PER", "2"}}}
+	assert.Equal(t, 4, placeInstances(app, fits))
+
+	app = &simulatedApp{Instances: &instances, Constraints: [][]string{{"rack", "CLUSTER"}}}
+	assert.Equal(t, 6, placeInstances(app, fits))
+
+	instances = 20
+	assert.Equal(t, 10, placeInstances(app, fits))
+
+	app = &simulatedApp{Instances: &instances, Constraints: [][]string{{}, {"hostname"}}}
+	assert.Equal(t, 15, placeInstances(app, fits))
+}
@@ -1,25 +1,157 @@
 package deployment
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-cli/pkg/cli"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
+// actionNames are the short names of the deployment actions displayed in the deployment table.
+var actionNames = map[string]string{
+	"ResolveArtifacts":   "artifacts",
+	"ScaleApplication":   "scale",
+	"StartApplication":   "start",
+	"StopApplication":    "stop",
+	"RestartApplication": "restart",
+	"ScalePod":           "scale",
+	"StartPod":           "start",
+	"StopPod":            "stop",
+	"RestartPod":         "restart",
+	"KillAllOldTasksOf":  "kill-tasks",
+}
+
+// deploymentItem holds the fields of a deployment displayed in the deployment table.
+type deploymentItem struct {
+	ID             string   `json:"id"`
+	AffectedApps   []string `json:"affectedApps"`
+	AffectedPods   []string `json:"affectedPods"`
+	CurrentStep    int      `json:"currentStep"`
+	TotalSteps     int      `json:"totalSteps"`
+	CurrentActions []struct {
+		Action string `json:"action"`
+		App    string `json:"app"`
+		Pod    string `json:"pod"`
+	} `json:"currentActions"`
+}
+
 func newCmdMarathonDeploymentList(ctx api.Context) *cobra.Command {
-	var json bool
+	var jsonOutput bool
 	var quiet bool
 
 	cmd := &cobra.Command{
-		Use:   "list",
+		Use:   "list [<app-id>]",
 		Short: "Print a list of currently deployed applications.",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			appID := ""
+			if len(args) == 1 {
+				appID = args[0]
+			}
+			return deploymentList(ctx, client, appID, jsonOutput, quiet)
 		},
 	}
 
-	cmd.Flags().BoolVar(&json, "json", false, "Print JSON-formatted data.")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print JSON-formatted data.")
 	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Display IDs only for list.")
 
 	return cmd
 }
+
+func deploymentList(ctx api.Context, client *marathon.Client, appID string, jsonOutput bool, quiet bool) error {
+	rawDeployments, err := client.RawDeployments()
+	if err != nil {
+		return err
+	}
+
+	raw := make([]json.RawMessage, 0, len(rawDeployments))
+	deployments := make([]deploymentItem, 0, len(rawDeployments))
+	for _, rawDeployment := range rawDeployments {
+		var deployment deploymentItem
+		if err := json.Unmarshal(rawDeployment, &deployment); err != nil {
+			return err
+		}
+		if appID == "" || affects(deployment, marathon.NormalizeAppID(appID)) {
+			raw = append(raw, rawDeployment)
+			deployments = append(deployments, deployment)
+		}
+	}
+
+	if quiet {
+		for _, deployment := range deployments {
+			fmt.Fprintln(ctx.Out(), deployment.ID)
+		}
+		return nil
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(ctx.Out())
+		enc.SetIndent("", "    ")
+		return enc.Encode(raw)
+	}
+
+	if len(deployments) == 0 {
+		if appID != "" {
+			return fmt.Errorf("there are no deployments for '%s'", marathon.NormalizeAppID(appID))
+		}
+		return errors.New("there are no deployments")
+	}
+
+	table := cli.NewTable(ctx.Out(), []string{"APP", "POD", "ACTION", "PROGRESS", "ID"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	for _, deployment := range deployments {
+		table.Append([]string{
+			strings.Join(deployment.AffectedApps, "\n"),
+			strings.Join(deployment.AffectedPods, "\n"),
+			formatActions(deployment),
+			fmt.Sprintf("%d/%d", deployment.CurrentStep-1, deployment.TotalSteps),
+			deployment.ID,
+		})
+	}
+	table.Render()
+
+	return nil
+}
+
+// affects returns whether the deployment affects the app or pod with the given ID.
+func affects(deployment deploymentItem, id string) bool {
+	for _, affected := range append(deployment.AffectedApps, deployment.AffectedPods...) {
+		if affected == id {
+			return true
+		}
+	}
+	return false
+}
+
+// formatActions returns the current actions of a deployment, along with the app or pod
+// they apply to when they don't all apply to the same one.
+func formatActions(deployment deploymentItem) string {
+	resources := make(map[string]bool)
+	for _, action := range deployment.CurrentActions {
+		resources[action.App+action.Pod] = true
+	}
+
+	actions := make([]string, 0, len(deployment.CurrentActions))
+	for _, action := range deployment.CurrentActions {
+		name, ok := actionNames[action.Action]
+		if !ok {
+			name = action.Action
+		}
+		if len(resources) > 1 {
+			name += " " + action.App + action.Pod
+		}
+		actions = append(actions, name)
+	}
+	return strings.Join(actions, "\n")
+}
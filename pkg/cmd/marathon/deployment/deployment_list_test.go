@@ -0,0 +1,65 @@
+package deployment
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deploymentsFixture = `[
+	{"id":"d1","version":"2020-01-01T00:00:00.000Z","affectedApps":["/web"],"affectedPods":[],"currentStep":2,"totalSteps":3,
+	 "steps":[],"currentActions":[{"action":"RestartApplication","app":"/web","readinessCheckResults":[]}],"unknownField":true},
+	{"id":"d2","version":"2020-01-01T00:00:00.000Z","affectedApps":["/api"],"affectedPods":["/db"],"currentStep":1,"totalSteps":1,
+	 "steps":[],"currentActions":[{"action":"ScaleApplication","app":"/api"},{"action":"StartPod","pod":"/db"}]}
+]`
+
+func listServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/service/marathon/v2/deployments", r.URL.Path)
+		fmt.Fprint(w, deploymentsFixture)
+	}))
+}
+
+func TestDeploymentList(t *testing.T) {
+	ts := listServer(t)
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = deploymentList(ctx, client, "", false, false)
+	require.NoError(t, err)
+	expected := "  APP   POD    ACTION    PROGRESS  ID  \n" +
+		"  /web       restart     1/3       d1  \n" +
+		"  /api  /db  scale /api  0/1       d2  \n" +
+		"             start /db                 \n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestDeploymentListFilters(t *testing.T) {
+	ts := listServer(t)
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = deploymentList(ctx, client, "db", false, true)
+	require.NoError(t, err)
+	assert.Equal(t, "d2\n", out.String())
+
+	out.Reset()
+	err = deploymentList(ctx, client, "/web", true, false)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `"unknownField": true`)
+	assert.NotContains(t, out.String(), "d2")
+
+	err = deploymentList(ctx, client, "/other", false, false)
+	assert.EqualError(t, err, "there are no deployments for '/other'")
+}
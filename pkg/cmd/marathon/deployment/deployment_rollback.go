@@ -1,17 +1,34 @@
 package deployment
 
 import (
+	"fmt"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonDeploymentRollback(ctx api.Context) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "rollback",
-		Short: "Remove a deployed application.",
+		Use:   "rollback <deployment-id>",
+		Short: "Cancel an in-progress deployment and revert its changes.",
+		Long: `Cancel an in-progress deployment and revert its changes.
+
+Marathon creates a new deployment reverting the changes already made by the
+cancelled one, its ID is printed.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			deploymentID, err := client.CancelDeployment(args[0], true)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(ctx.Out(), "Created deployment %s\n", deploymentID)
+			return nil
 		},
 	}
 	return cmd
@@ -2,16 +2,27 @@ package deployment
 
 import (
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonDeploymentStop(ctx api.Context) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "stop",
+		Use:   "stop <deployment-id>",
 		Short: "Cancel the in-progress deployment of an application.",
+		Long: `Cancel the in-progress deployment of an application.
+
+The deployment is stopped as is, the changes it already made are kept.
+Use 'dcos marathon deployment rollback' to revert them.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			_, err = client.CancelDeployment(args[0], false)
+			return err
 		},
 	}
 	return cmd
@@ -1,26 +1,61 @@
 package deployment
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonDeploymentWatch(ctx api.Context) *cobra.Command {
 	var maxCount int
 	var interval int
+	var timeout time.Duration
 
 	cmd := &cobra.Command{
-		Use:   "watch",
+		Use:   "watch <deployment-id>",
 		Short: "Monitor deployments.",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			return deploymentWatch(ctx, client, args[0], timeout)
 		},
 	}
 
 	cmd.Flags().IntVar(&maxCount, "max-count", 0, "Maximum number of entries to fetch and return.")
-	// TODO: Python version has no description
 	cmd.Flags().IntVar(&interval, "interval", 0, "")
+	cmd.Flags().MarkDeprecated("max-count", "deployments are now followed through the Marathon event stream.")
+	cmd.Flags().MarkDeprecated("interval", "deployments are now followed through the Marathon event stream.")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Maximum time to wait for the deployment to finish, e.g. 10m (0 means no timeout).")
 
 	return cmd
 }
+
+func deploymentWatch(ctx api.Context, client *marathon.Client, deploymentID string, timeout time.Duration) error {
+	// Subscribe before looking up the deployment so that no event can be missed in-between.
+	events, unsubscribe, err := client.Events(marathon.DeploymentEventTypes...)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	deployment, err := client.Deployment(deploymentID)
+	if err != nil {
+		return err
+	}
+	if deployment == nil {
+		return marathon.NewDeploymentNotFoundError(deploymentID)
+	}
+
+	fmt.Fprintf(ctx.Out(), "Watching deployment %s (%d steps) for %s\n",
+		deployment.ID, deployment.TotalSteps, strings.Join(append(deployment.AffectedApps, deployment.AffectedPods...), ", "))
+
+	return marathon.FollowDeployment(ctx.Out(), events, *deployment, timeout)
+}
@@ -0,0 +1,126 @@
+package deployment
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deploymentID = "5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43"
+
+func watchServer(t *testing.T, events []string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/service/marathon/v2/events":
+			assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+			assert.Contains(t, r.URL.Query()["event_type"], "deployment_success")
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			for _, event := range events {
+				fmt.Fprint(w, event)
+			}
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		case "/service/marathon/v2/deployments":
+			fmt.Fprintf(w, `[{"id":"%s","version":"2020-01-01T00:00:00.000Z","affectedApps":["/app"],"affectedPods":[],"currentStep":1,"totalSteps":2,"steps":[],"currentActions":[]}]`, deploymentID)
+		default:
+			t.Fatalf("unexpected call to endpoint %s", r.URL.Path)
+		}
+	}))
+}
+
+func sseEvent(eventType string, data string) string {
+	return fmt.Sprintf("event: %s\ndata: %s\n\n", eventType, data)
+}
+
+func TestDeploymentWatchSuccess(t *testing.T) {
+	plan := fmt.Sprintf(`{"id":"%s","steps":[]}`, deploymentID)
+	ts := watchServer(t, []string{
+		sseEvent("deployment_info", fmt.Sprintf(`{"eventType":"deployment_info","plan":%s,"currentStep":{"actions":[{"action":"StartApplication","app":"/app"}]}}`, plan)),
+		sseEvent("status_update_event", `{"eventType":"status_update_event","appId":"/app","taskId":"app.1","taskStatus":"TASK_RUNNING","host":"10.0.0.1"}`),
+		sseEvent("status_update_event", `{"eventType":"status_update_event","appId":"/other","taskId":"other.1","taskStatus":"TASK_RUNNING","host":"10.0.0.2"}`),
+		sseEvent("deployment_step_success", fmt.Sprintf(`{"eventType":"deployment_step_success","plan":%s,"currentStep":{"actions":[{"action":"StartApplication","app":"/app"}]}}`, plan)),
+		sseEvent("deployment_step_success", `{"eventType":"deployment_step_success","plan":{"id":"other-deployment"},"currentStep":{"actions":[]}}`),
+		sseEvent("deployment_success", fmt.Sprintf(`{"eventType":"deployment_success","id":"%s","plan":%s}`, deploymentID, plan)),
+	})
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = deploymentWatch(ctx, client, deploymentID, 0)
+	require.NoError(t, err)
+
+	expected := "Watching deployment " + deploymentID + " (2 steps) for /app\n" +
+		"[1/2] Started StartApplication /app\n" +
+		"  /app: task app.1 is TASK_RUNNING on 10.0.0.1\n" +
+		"[1/2] Succeeded StartApplication /app\n" +
+		"Deployment " + deploymentID + " succeeded\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestDeploymentWatchFailure(t *testing.T) {
+	ts := watchServer(t, []string{
+		sseEvent("deployment_failed", fmt.Sprintf(`{"eventType":"deployment_failed","id":"%s"}`, deploymentID)),
+	})
+	defer ts.Close()
+
+	ctx, _ := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = deploymentWatch(ctx, client, deploymentID, 0)
+	assert.EqualError(t, err, "deployment "+deploymentID+" failed")
+	require.IsType(t, &marathon.WaitError{}, err)
+	assert.Equal(t, marathon.ExitCodeDeploymentFailed, err.(*marathon.WaitError).ExitCode())
+}
+
+func TestDeploymentWatchTimeout(t *testing.T) {
+	ts := watchServer(t, nil)
+	defer ts.Close()
+
+	ctx, _ := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = deploymentWatch(ctx, client, deploymentID, 10*time.Millisecond)
+	assert.EqualError(t, err, "timed out waiting for deployment "+deploymentID+" after 10ms")
+	require.IsType(t, &marathon.WaitError{}, err)
+	assert.Equal(t, marathon.ExitCodeTimeout, err.(*marathon.WaitError).ExitCode())
+}
+
+func TestDeploymentWatchNotFound(t *testing.T) {
+	ts := watchServer(t, nil)
+	defer ts.Close()
+
+	ctx, _ := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = deploymentWatch(ctx, client, "unknown", 0)
+	assert.EqualError(t, err, "deployment unknown is not in progress, its outcome is unknown")
+	require.IsType(t, &marathon.WaitError{}, err)
+	assert.Equal(t, marathon.ExitCodeDeploymentNotFound, err.(*marathon.WaitError).ExitCode())
+}
+
+func newContext(ts *httptest.Server) (*mock.Context, *bytes.Buffer) {
+	out := new(bytes.Buffer)
+	env := mock.NewEnvironment()
+	env.Out = out
+	ctx := mock.NewContext(env)
+	cluster := config.NewCluster(nil)
+	cluster.SetURL(ts.URL)
+	ctx.SetCluster(cluster)
+	return ctx, out
+}
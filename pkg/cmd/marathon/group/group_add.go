@@ -1,23 +1,71 @@
 package group
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonGroupAdd(ctx api.Context) *cobra.Command {
 	var groupID string
+	var waitOpts marathon.WaitOptions
 
 	cmd := &cobra.Command{
 		Use:   "add",
 		Short: "Add a group.",
+		Args:  cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if groupID != "" && len(args) > 0 {
+				return fmt.Errorf("the flag 'id' cannot be used with a group resource")
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			if len(args) > 0 {
+				return marathonGroupAdd(ctx, *client, args[0], groupID, waitOpts)
+			}
+			return marathonGroupAdd(ctx, *client, "", groupID, waitOpts)
 		},
 	}
 
 	cmd.Flags().StringVar(&groupID, "id", "", "The group ID to add.")
+	marathon.AddWaitFlags(cmd, &waitOpts)
 
 	return cmd
 }
+
+func marathonGroupAdd(ctx api.Context, client marathon.Client, groupFile string, groupID string, waitOpts marathon.WaitOptions) error {
+	events, unsubscribe, err := client.SubscribeDeploymentEvents(waitOpts)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	var deploymentID string
+
+	if groupID != "" {
+		deploymentID, err = client.CreateGroup(map[string]interface{}{"id": groupID})
+	} else {
+		deploymentID, err = client.AddGroup(ctx, groupFile)
+	}
+	if err != nil {
+		if err == marathon.ErrCannotReadGroupDefinition {
+			return fmt.Errorf("can't read from resource: %s. Please check that it exists", groupFile)
+		} else if syntaxError, ok := err.(*json.SyntaxError); ok {
+			return fmt.Errorf("error loading JSON: %s", syntaxError.Error())
+		}
+		return err
+	}
+	fmt.Fprintf(ctx.Out(), "Created deployment %s\n", deploymentID)
+	if !waitOpts.Wait {
+		return nil
+	}
+	return client.WaitForDeployment(ctx.Out(), events, deploymentID, waitOpts.Timeout)
+}
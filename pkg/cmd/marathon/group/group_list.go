@@ -1,23 +1,76 @@
 package group
 
 import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-cli/pkg/cli"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonGroupList(ctx api.Context) *cobra.Command {
-	var json bool
+	var jsonOutput bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "Print the list of groups.",
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			return groupList(ctx, client, jsonOutput)
 		},
 	}
 
-	cmd.Flags().BoolVar(&json, "json", false, "Print JSON-formatted data.")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print JSON-formatted data.")
 
 	return cmd
 }
+
+func groupList(ctx api.Context, client *marathon.Client, jsonOutput bool) error {
+	root, err := client.Group("/", "")
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(ctx.Out())
+		enc.SetIndent("", "    ")
+
+		return enc.Encode(root.Groups)
+	}
+
+	var groups []marathon.Group
+	var flatten func([]marathon.Group)
+	flatten = func(nested []marathon.Group) {
+		for _, group := range nested {
+			groups = append(groups, group)
+			flatten(group.Groups)
+		}
+	}
+	flatten(root.Groups)
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].ID < groups[j].ID
+	})
+
+	table := cli.NewTable(ctx.Out(), []string{"ID", "APPS", "ENFORCE ROLE"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	for _, group := range groups {
+		table.Append([]string{
+			group.ID,
+			strconv.Itoa(group.AppCount()),
+			strconv.FormatBool(group.EnforceRole),
+		})
+	}
+	table.Render()
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package group
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rootGroup = `{
+  "id": "/",
+  "apps": [{"id": "/root-app"}],
+  "groups": [
+    {
+      "id": "/prod",
+      "apps": [{"id": "/prod/web"}],
+      "groups": [
+        {"id": "/prod/db", "apps": [{"id": "/prod/db/master"}, {"id": "/prod/db/replica"}], "groups": [], "enforceRole": true}
+      ]
+    },
+    {"id": "/dev", "apps": [], "groups": []}
+  ]
+}`
+
+func TestGroupList(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/service/marathon/v2/groups/", r.URL.String())
+		w.Write([]byte(rootGroup))
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts, "")
+
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = groupList(ctx, client, false)
+	assert.NoError(t, err)
+	expected := "     ID     APPS  ENFORCE ROLE  \n" +
+		"  /dev      0     false         \n" +
+		"  /prod     3     false         \n" +
+		"  /prod/db  2     true          \n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestGroupListJSON(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": "/", "groups": [{"id": "/prod", "apps": [{"id": "/prod/web", "unknownField": 1}], "groups": []}]}`))
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts, "")
+
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = groupList(ctx, client, true)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id": "/prod", "apps": [{"id": "/prod/web", "unknownField": 1}], "groups": []}]`, out.String())
+}
+
+func newContext(ts *httptest.Server, input string) (*mock.Context, *bytes.Buffer) {
+	out := new(bytes.Buffer)
+	env := mock.NewEnvironment()
+	env.Input = strings.NewReader(input)
+	env.Out = out
+	ctx := mock.NewContext(env)
+	cluster := config.NewCluster(nil)
+	cluster.SetURL(ts.URL)
+	ctx.SetCluster(cluster)
+	return ctx, out
+}
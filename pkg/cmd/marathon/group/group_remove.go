@@ -1,23 +1,51 @@
 package group
 
 import (
+	"fmt"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	goMarathon "github.com/gambol99/go-marathon"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonGroupRemove(ctx api.Context) *cobra.Command {
 	var force bool
+	var waitOpts marathon.WaitOptions
 
 	cmd := &cobra.Command{
 		Use:   "remove",
 		Short: "Remove a group.",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			return marathonGroupRemove(ctx, *client, args[0], force, waitOpts)
 		},
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Disable checks in Marathon during updates.")
+	marathon.AddWaitFlags(cmd, &waitOpts)
 
 	return cmd
 }
+
+func marathonGroupRemove(ctx api.Context, client marathon.Client, groupID string, force bool, waitOpts marathon.WaitOptions) error {
+	events, unsubscribe, err := client.SubscribeDeploymentEvents(waitOpts)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	deploymentID, err := client.API.DeleteGroup(groupID, force)
+	if apiErr, ok := err.(*goMarathon.APIError); ok && apiErr.ErrCode == goMarathon.ErrCodeNotFound {
+		return fmt.Errorf(`group '%s' does not exist`, marathon.NormalizeAppID(groupID))
+	}
+	if err != nil || !waitOpts.Wait {
+		return err
+	}
+	return client.WaitForDeployment(ctx.Out(), events, deploymentID.DeploymentID, waitOpts.Timeout)
+}
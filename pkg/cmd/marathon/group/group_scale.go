@@ -1,24 +1,61 @@
 package group
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonGroupScale(ctx api.Context) *cobra.Command {
 	var force bool
+	var waitOpts marathon.WaitOptions
 
 	cmd := &cobra.Command{
-		Use:                "scale",
-		Short:              "Scale a group.",
-		DisableFlagParsing: true,
+		Use:   "scale <group-id> <scale-factor>",
+		Short: "Scale a group.",
+		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			scaleFactor, err := strconv.ParseFloat(args[1], 64)
+			if err != nil {
+				return fmt.Errorf("error parsing string as float: %s", args[1])
+			}
+			if scaleFactor < 0 {
+				return fmt.Errorf("scale factor must be a non-negative number: %s", args[1])
+			}
+
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			return marathonGroupScale(ctx, *client, args[0], scaleFactor, force, waitOpts)
 		},
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Disable checks in Marathon during updates.")
+	marathon.AddWaitFlags(cmd, &waitOpts)
 
 	return cmd
 }
+
+func marathonGroupScale(ctx api.Context, client marathon.Client, groupID string, scaleFactor float64, force bool, waitOpts marathon.WaitOptions) error {
+	events, unsubscribe, err := client.SubscribeDeploymentEvents(waitOpts)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	deploymentID, err := client.ScaleGroup(groupID, scaleFactor, force)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ctx.Out(), "Created deployment %s\n", deploymentID)
+	if !waitOpts.Wait {
+		return nil
+	}
+	return client.WaitForDeployment(ctx.Out(), events, deploymentID, waitOpts.Timeout)
+}
@@ -1,8 +1,12 @@
 package group
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
@@ -17,8 +21,14 @@ func newCmdMarathonGroupShow(ctx api.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "show",
 		Short: "Print a detailed list of groups.",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			return groupShow(ctx, client, args[0], groupVersion)
 		},
 	}
 
@@ -26,3 +36,47 @@ func newCmdMarathonGroupShow(ctx api.Context) *cobra.Command {
 
 	return cmd
 }
+
+func groupShow(ctx api.Context, client *marathon.Client, groupID string, groupVersion string) error {
+	targetVersion := ""
+	if groupVersion != "" {
+		var err error
+		targetVersion, err = calculateVersion(client, groupID, groupVersion)
+		if err != nil {
+			return err
+		}
+	}
+
+	group, err := client.Group(groupID, targetVersion)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(ctx.Out())
+	enc.SetIndent("", "    ")
+	return enc.Encode(group)
+}
+
+func calculateVersion(client *marathon.Client, groupID string, version string) (string, error) {
+	// check if the version string is an integer which indicates that the user
+	// wants that many behind the latest version
+	versionsBehind, err := strconv.Atoi(version)
+	if err != nil {
+		// if it fails to parse, the user must have given a specific version string
+		return version, nil
+	}
+
+	if versionsBehind >= 0 {
+		return "", fmt.Errorf("relative versions must be negative: %d", versionsBehind)
+	}
+	versionsBehind = -1 * versionsBehind
+
+	versions, err := client.GroupVersions(groupID)
+	if err != nil {
+		return "", err
+	}
+	if len(versions) <= versionsBehind {
+		return "", fmt.Errorf("group '%s' only has %d version(s)", marathon.NormalizeAppID(groupID), len(versions))
+	}
+	return versions[versionsBehind], nil
+}
@@ -1,23 +1,80 @@
 package group
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonGroupUpdate(ctx api.Context) *cobra.Command {
 	var force bool
+	var waitOpts marathon.WaitOptions
 
 	cmd := &cobra.Command{
-		Use:   "update",
+		Use:   "update <group-id> [<properties>...]",
 		Short: "Update a group.",
+		Long: `Update a group.
+
+Properties are given in the form key=value, if none are given the group
+definition is read from stdin.`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			return marathonGroupUpdate(ctx, *client, args[0], args[1:], force, waitOpts)
 		},
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Disable checks in Marathon during updates.")
+	marathon.AddWaitFlags(cmd, &waitOpts)
 
 	return cmd
 }
+
+func marathonGroupUpdate(ctx api.Context, client marathon.Client, groupID string, properties []string, force bool, waitOpts marathon.WaitOptions) error {
+	// Ensure that the group exists.
+	_, err := client.Group(groupID, "")
+	if err != nil {
+		return err
+	}
+
+	var changes map[string]interface{}
+	if len(properties) > 0 {
+		changes, err = marathon.ParseProperties(properties)
+		if err != nil {
+			return err
+		}
+	} else {
+		data, err := ioutil.ReadAll(ctx.Input())
+		if err != nil {
+			return err
+		}
+		if err = json.Unmarshal(data, &changes); err != nil {
+			return fmt.Errorf("error loading JSON: %s", err)
+		}
+	}
+
+	events, unsubscribe, err := client.SubscribeDeploymentEvents(waitOpts)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	deploymentID, err := client.UpdateGroup(groupID, changes, force)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ctx.Out(), "Created deployment %s\n", deploymentID)
+	if !waitOpts.Wait {
+		return nil
+	}
+	return client.WaitForDeployment(ctx.Out(), events, deploymentID, waitOpts.Timeout)
+}
@@ -0,0 +1,152 @@
+package group
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		properties []string
+		input      string
+		expected   map[string]interface{}
+	}{
+		{
+			name:       "properties",
+			properties: []string{"enforceRole=true", `dependencies=["/dev"]`},
+			expected: map[string]interface{}{
+				"enforceRole":  true,
+				"dependencies": []interface{}{"/dev"},
+			},
+		},
+		{
+			name:     "stdin",
+			input:    `{"enforceRole": false}`,
+			expected: map[string]interface{}{"enforceRole": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					assert.Equal(t, "/service/marathon/v2/groups/prod", r.URL.String())
+					w.Write([]byte(`{"id":"/prod","apps":[],"groups":[]}`))
+				case http.MethodPut:
+					assert.Equal(t, "/service/marathon/v2/groups/prod?force=true", r.URL.String())
+
+					var changes map[string]interface{}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&changes))
+					assert.Equal(t, tt.expected, changes)
+
+					w.Write([]byte(`{"deploymentId":"5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43","version":"2015-09-29T15:59:51.164Z"}`))
+				}
+			}))
+			defer ts.Close()
+
+			ctx, out := newContext(ts, tt.input)
+
+			client, err := marathon.NewClient(ctx)
+			require.NoError(t, err)
+
+			err = marathonGroupUpdate(ctx, *client, "prod", tt.properties, true, marathon.WaitOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, "Created deployment 5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43\n", out.String())
+		})
+	}
+}
+
+func TestGroupScale(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/service/marathon/v2/groups/prod", r.URL.String())
+
+		var changes map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&changes))
+		assert.Equal(t, map[string]interface{}{"scaleBy": 1.5}, changes)
+
+		w.Write([]byte(`{"deploymentId":"5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43","version":"2015-09-29T15:59:51.164Z"}`))
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts, "")
+
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = marathonGroupScale(ctx, *client, "/prod", 1.5, false, marathon.WaitOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Created deployment 5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43\n", out.String())
+}
+
+func TestGroupScaleWait(t *testing.T) {
+	deployed := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/service/marathon/v2/events":
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-deployed
+			fmt.Fprint(w, "event: deployment_failed\ndata: {\"eventType\":\"deployment_failed\",\"id\":\"5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43\"}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		case "/service/marathon/v2/deployments":
+			w.Write([]byte(`[]`))
+		case "/service/marathon/v2/groups/prod":
+			close(deployed)
+			w.Write([]byte(`{"deploymentId":"5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43","version":"2015-09-29T15:59:51.164Z"}`))
+		default:
+			t.Fatalf("unexpected %s request on %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts, "")
+
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = marathonGroupScale(ctx, *client, "/prod", 1.5, false, marathon.WaitOptions{Wait: true})
+	require.IsType(t, &marathon.WaitError{}, err)
+	assert.Equal(t, marathon.ExitCodeDeploymentFailed, err.(*marathon.WaitError).ExitCode())
+	assert.Contains(t, out.String(), "Waiting for deployment 5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43 to finish\n")
+}
+
+func TestGroupShowRelativeVersion(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.String() {
+		case "/service/marathon/v2/groups/prod/versions":
+			w.Write([]byte(`["2020-01-02T00:00:00.000Z","2020-01-01T00:00:00.000Z"]`))
+		case "/service/marathon/v2/groups/prod/versions/2020-01-01T00:00:00.000Z":
+			w.Write([]byte(`{"id":"/prod","apps":[],"groups":[],"version":"2020-01-01T00:00:00.000Z"}`))
+		default:
+			t.Fatalf("unexpected call to endpoint %s", r.URL.String())
+		}
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts, "")
+
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = groupShow(ctx, client, "prod", "-1")
+	require.NoError(t, err)
+
+	var group marathon.Group
+	require.NoError(t, json.Unmarshal(out.Bytes(), &group))
+	assert.Equal(t, "2020-01-01T00:00:00.000Z", group.Version)
+
+	err = groupShow(ctx, client, "prod", "-2")
+	assert.EqualError(t, err, "group '/prod' only has 2 version(s)")
+}
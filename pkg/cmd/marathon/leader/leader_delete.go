@@ -1,17 +1,34 @@
 package leader
 
 import (
+	"fmt"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonLeaderDelete(ctx api.Context) *cobra.Command {
 	cmd := &cobra.Command{
-		Use: "delete",
+		Use:   "delete",
+		Short: "Make the leading Marathon instance abdicate.",
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			return leaderDelete(ctx, client)
 		},
 	}
 	return cmd
 }
+
+func leaderDelete(ctx api.Context, client *marathon.Client) error {
+	message, err := client.DeleteLeader()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(ctx.Out(), message)
+	return nil
+}
@@ -1,22 +1,57 @@
 package leader
 
 import (
+	"encoding/json"
+	"net"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-cli/pkg/cli"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonLeaderShow(ctx api.Context) *cobra.Command {
-	var json bool
+	var jsonOutput bool
 
 	cmd := &cobra.Command{
-		Use: "show",
+		Use:   "show",
+		Short: "Show the leading Marathon instance.",
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			return leaderShow(ctx, client, jsonOutput)
 		},
 	}
 
-	cmd.Flags().BoolVar(&json, "json", false, "Print JSON-formatted data.")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print JSON-formatted data.")
 
 	return cmd
 }
+
+func leaderShow(ctx api.Context, client *marathon.Client, jsonOutput bool) error {
+	leader, err := client.Leader()
+	if err != nil {
+		return err
+	}
+
+	host, port, err := net.SplitHostPort(leader)
+	if err != nil {
+		host = leader
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(ctx.Out())
+		enc.SetIndent("", "    ")
+		return enc.Encode(map[string]string{"host": host, "port": port})
+	}
+
+	table := cli.NewTable(ctx.Out(), []string{"HOST", "PORT"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Append([]string{host, port})
+	table.Render()
+	return nil
+}
@@ -0,0 +1,61 @@
+package leader
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaderShow(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/service/marathon/v2/leader", r.URL.String())
+		assert.Equal(t, "GET", r.Method)
+		w.Write([]byte(`{"leader":"10.0.1.2:8080"}`))
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, leaderShow(ctx, client, false))
+	assert.Equal(t, "    HOST    PORT  \n  10.0.1.2  8080  \n", out.String())
+
+	out.Reset()
+	require.NoError(t, leaderShow(ctx, client, true))
+	assert.JSONEq(t, `{"host":"10.0.1.2","port":"8080"}`, out.String())
+}
+
+func TestLeaderDelete(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/service/marathon/v2/leader", r.URL.String())
+		assert.Equal(t, "DELETE", r.Method)
+		w.Write([]byte(`{"message":"Leadership abdicated"}`))
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, leaderDelete(ctx, client))
+	assert.Equal(t, "Leadership abdicated\n", out.String())
+}
+
+func newContext(ts *httptest.Server) (*mock.Context, *bytes.Buffer) {
+	out := new(bytes.Buffer)
+	env := mock.NewEnvironment()
+	env.Out = out
+	ctx := mock.NewContext(env)
+	cluster := config.NewCluster(nil)
+	cluster.SetURL(ts.URL)
+	ctx.SetCluster(cluster)
+	return ctx, out
+}
@@ -1,18 +1,36 @@
 package marathon
 
 import (
+	"fmt"
+
 	"github.com/dcos/dcos-cli/api"
+	cliVersion "github.com/dcos/dcos-cli/pkg/cli/version"
 	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/app"
 	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/debug"
 	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/deployment"
 	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/group"
 	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/leader"
 	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/pod"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
 	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/task"
 	"github.com/spf13/cobra"
 )
 
+// configSchemaJSON is the schema of the configuration of the Marathon subcommand.
+const configSchemaJSON = `{
+    "$schema": "http://json-schema.org/schema#",
+    "additionalProperties": false,
+    "properties": {
+        "url": {
+            "default": "http://localhost:8080",
+            "description": "Base URL for talking to Marathon. It overwrites the value specified in core.dcos_url",
+            "format": "uri",
+            "title": "Marathon base URL",
+            "type": "string"
+        }
+    },
+    "type": "object"
+}`
+
 // NewCommand creates the `dcos package` subcommand.
 func NewCommand(ctx api.Context) *cobra.Command {
 	var configSchema bool
@@ -23,14 +41,20 @@ func NewCommand(ctx api.Context) *cobra.Command {
 		Use:   "marathon",
 		Short: "Deploy and manage applications to DC/OS",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			/*
-				if len(args) == 0 {
-					return cmd.Help()
-				}
+			switch {
+			case configSchema:
+				fmt.Fprintln(ctx.Out(), configSchemaJSON)
+			case info:
+				fmt.Fprintln(ctx.Out(), cmd.Short)
+			case version:
+				fmt.Fprintf(ctx.Out(), "dcos-marathon version %s\n", cliVersion.Version())
+			case len(args) == 0:
+				return cmd.Help()
+			default:
 				fmt.Fprintln(ctx.ErrOut(), cmd.UsageString())
 				return fmt.Errorf("unknown command %s", args[0])
-			*/
-			return python.InvokePythonCLI(ctx)
+			}
+			return nil
 		},
 	}
 
@@ -40,7 +64,11 @@ func NewCommand(ctx api.Context) *cobra.Command {
 
 	cmd.AddCommand(
 		newCmdMarathonAbout(ctx),
+		newCmdMarathonApply(ctx),
 		newCmdMarathonDelay(ctx),
+		newCmdMarathonEvents(ctx),
+		newCmdMarathonExport(ctx),
+		newCmdMarathonImport(ctx),
 		newCmdMarathonPing(ctx),
 		newCmdMarathonPlugin(ctx),
 		app.NewCmdMarathonApp(ctx),
@@ -1,6 +1,7 @@
 package marathon
 
 import (
+	"bytes"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -19,7 +20,7 @@ func TestMarathonAbout(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	ctx := newContext(ts)
+	ctx, _ := newContext(ts)
 
 	client, err := marathon.NewClient(ctx)
 	assert.NoError(t, err)
@@ -37,7 +38,7 @@ func TestMarathonAboutClientError(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	ctx := newContext(ts)
+	ctx, _ := newContext(ts)
 
 	client, err := marathon.NewClient(ctx)
 	assert.NoError(t, err)
@@ -46,11 +47,13 @@ func TestMarathonAboutClientError(t *testing.T) {
 	assert.EqualError(t, err, `HTTP 500 error`)
 }
 
-func newContext(ts *httptest.Server) *mock.Context {
+func newContext(ts *httptest.Server) (*mock.Context, *bytes.Buffer) {
+	out := new(bytes.Buffer)
 	env := mock.NewEnvironment()
+	env.Out = out
 	ctx := mock.NewContext(env)
 	cluster := config.NewCluster(nil)
 	cluster.SetURL(ts.URL)
 	ctx.SetCluster(cluster)
-	return ctx
+	return ctx, out
 }
@@ -0,0 +1,117 @@
+package marathon
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/spf13/cobra"
+)
+
+type applyOptions struct {
+	location   string
+	dryRun     bool
+	pruneGroup string
+	force      bool
+}
+
+func newCmdMarathonApply(ctx api.Context) *cobra.Command {
+	var opts applyOptions
+
+	cmd := &cobra.Command{
+		Use:   "apply -f <dir|file>",
+		Short: "Apply app, pod and group definitions to Marathon.",
+		Long: `Apply app, pod and group definitions to Marathon.
+
+Definitions are loaded from a JSON file or from all the JSON files of a
+directory. Groups, apps and pods which don't exist yet are created, the ones
+which differ from their definition are updated. With --prune, the apps and
+pods deployed under the given group which aren't defined are removed, groups
+are never removed.
+
+Deployed groups, apps and pods are only compared on the top-level fields set
+in their definition, as Marathon fills in default values for the other ones.
+These fields are compared as a whole, an env variable or a label removed from
+a definition is thus removed from the deployed app. A group
+is compared on its own fields such as its dependencies, its apps, pods and
+subgroups being compared to their own definition.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			return marathonApply(ctx, client, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.location, "filename", "f", "", "Path to a JSON definition or to a directory of JSON definitions.")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print the plan without applying it.")
+	cmd.Flags().StringVar(&opts.pruneGroup, "prune", "", "Remove the apps and pods deployed under this group which aren't defined.")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Disable checks in Marathon during updates.")
+	cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+func marathonApply(ctx api.Context, client *marathon.Client, opts applyOptions) error {
+	desired, err := marathon.LoadResources(opts.location)
+	if err != nil {
+		return err
+	}
+
+	live, err := client.LiveResources("/")
+	if err != nil {
+		return err
+	}
+
+	plan, err := marathon.ComputePlan(desired, live, opts.pruneGroup)
+	if err != nil {
+		return err
+	}
+	if len(plan) == 0 {
+		fmt.Fprintln(ctx.Out(), "Nothing to apply, all groups, apps and pods are up-to-date.")
+		return nil
+	}
+
+	printPlan(ctx, plan)
+	if opts.dryRun {
+		return nil
+	}
+
+	for _, step := range plan {
+		deploymentID, err := client.ApplyStep(step, opts.force)
+		if err != nil {
+			return fmt.Errorf("unable to %s %s '%s': %s", step.Action, step.Resource.Kind, step.Resource.ID, err)
+		}
+		fmt.Fprintf(ctx.Out(), "Created deployment %s to %s %s %s\n", deploymentID, step.Action, step.Resource.Kind, step.Resource.ID)
+	}
+	return nil
+}
+
+func printPlan(ctx api.Context, plan []marathon.PlanStep) {
+	counts := make(map[marathon.PlanAction]int)
+	for _, step := range plan {
+		counts[step.Action]++
+	}
+	fmt.Fprintf(ctx.Out(), "Plan: %d to create, %d to update, %d to delete.\n",
+		counts[marathon.ActionCreate], counts[marathon.ActionUpdate], counts[marathon.ActionDelete])
+
+	symbols := map[marathon.PlanAction]string{
+		marathon.ActionCreate: "+",
+		marathon.ActionUpdate: "~",
+		marathon.ActionDelete: "-",
+	}
+	for _, step := range plan {
+		fmt.Fprintf(ctx.Out(), "%s %s %s %s\n", symbols[step.Action], step.Action, step.Resource.Kind, step.Resource.ID)
+
+		var changes bytes.Buffer
+		marathon.PrintDiff(&changes, step.Changes, marathon.IsColored(ctx.Out()))
+		scanner := bufio.NewScanner(&changes)
+		for scanner.Scan() {
+			fmt.Fprintf(ctx.Out(), "    %s\n", scanner.Text())
+		}
+	}
+}
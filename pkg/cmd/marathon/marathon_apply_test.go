@@ -0,0 +1,116 @@
+package marathon
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const liveGroups = `{"id":"/","apps":[],"pods":[],"groups":[{"id":"/prod","apps":[
+	{"id":"/prod/web","cmd":"sleep 100","instances":1,"backoffFactor":1.15,"version":"2020-01-01T00:00:00.000Z"},
+	{"id":"/prod/old","cmd":"sleep 100","instances":1}
+],"pods":[],"groups":[]}]}`
+
+func applyServer(t *testing.T, calls *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /service/marathon/v2/groups/":
+			assert.Equal(t, []string{"group.groups", "group.apps", "group.pods"}, r.URL.Query()["embed"])
+			w.Write([]byte(liveGroups))
+		case "POST /service/marathon/v2/apps":
+			var app map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&app))
+			assert.Equal(t, "/prod/api", app["id"])
+			w.Header().Set("Marathon-Deployment-Id", "deployment-1")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		case "PUT /service/marathon/v2/apps/prod/web":
+			var app map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&app))
+			assert.Equal(t, float64(3), app["instances"])
+			w.Write([]byte(`{"deploymentId":"deployment-2","version":"2020-01-02T00:00:00.000Z"}`))
+		case "PUT /service/marathon/v2/groups/prod":
+			var group map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&group))
+			assert.Equal(t, map[string]interface{}{"id": "/prod", "dependencies": []interface{}{"/db"}}, group)
+			w.Write([]byte(`{"deploymentId":"deployment-4","version":"2020-01-02T00:00:00.000Z"}`))
+		case "DELETE /service/marathon/v2/apps/prod/old":
+			w.Write([]byte(`{"deploymentId":"deployment-3","version":"2020-01-02T00:00:00.000Z"}`))
+		default:
+			t.Fatalf("unexpected call to %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func applyDefinitions(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "marathon-apply")
+	require.NoError(t, err)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "prod.json"), []byte(`{"id":"/prod","dependencies":["/db"],"apps":[
+		{"id":"web","cmd":"sleep 100","instances":3},
+		{"id":"api","cmd":"sleep 100","instances":1}
+	]}`), 0600)
+	require.NoError(t, err)
+	return dir
+}
+
+func TestMarathonApplyDryRun(t *testing.T) {
+	var calls []string
+	ts := applyServer(t, &calls)
+	defer ts.Close()
+
+	dir := applyDefinitions(t)
+	defer os.RemoveAll(dir)
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = marathonApply(ctx, client, applyOptions{location: dir, dryRun: true, pruneGroup: "/prod"})
+	require.NoError(t, err)
+
+	expected := "Plan: 1 to create, 2 to update, 1 to delete.\n" +
+		"+ create app /prod/api\n" +
+		"~ update group /prod\n" +
+		"    + /dependencies: [\"/db\"]\n" +
+		"~ update app /prod/web\n" +
+		"    ~ /instances: 1 -> 3\n" +
+		"- delete app /prod/old\n"
+	assert.Equal(t, expected, out.String())
+	assert.Equal(t, []string{"GET /service/marathon/v2/groups/"}, calls)
+}
+
+func TestMarathonApply(t *testing.T) {
+	var calls []string
+	ts := applyServer(t, &calls)
+	defer ts.Close()
+
+	dir := applyDefinitions(t)
+	defer os.RemoveAll(dir)
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = marathonApply(ctx, client, applyOptions{location: dir})
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "Created deployment deployment-1 to create app /prod/api\n"+
+		"Created deployment deployment-4 to update group /prod\n"+
+		"Created deployment deployment-2 to update app /prod/web\n")
+	assert.Equal(t, []string{
+		"GET /service/marathon/v2/groups/",
+		"POST /service/marathon/v2/apps",
+		"PUT /service/marathon/v2/groups/prod",
+		"PUT /service/marathon/v2/apps/prod/web",
+	}, calls)
+}
@@ -1,8 +1,10 @@
 package marathon
 
 import (
+	"fmt"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
@@ -20,11 +22,24 @@ func newCmdMarathonDelay(ctx api.Context) *cobra.Command {
 
 func newCmdMarathonDelayReset(ctx api.Context) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "reset",
+		Use:   "reset <app-id|pod-id>",
 		Short: "Reset the current delay (if any) of the application.",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			return marathonDelayReset(ctx, client, args[0])
 		},
 	}
 	return cmd
 }
+
+func marathonDelayReset(ctx api.Context, client *marathon.Client, id string) error {
+	if err := client.ResetDelay(id); err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.Out(), "Delay on [%s] has been reset\n", marathon.NormalizeAppID(id))
+	return nil
+}
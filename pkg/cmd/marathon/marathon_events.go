@@ -0,0 +1,91 @@
+package marathon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/gobwas/glob"
+	"github.com/spf13/cobra"
+)
+
+type eventsOptions struct {
+	types   []string
+	app     string
+	jsonOut bool
+}
+
+func newCmdMarathonEvents(ctx api.Context) *cobra.Command {
+	var opts eventsOptions
+
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Tail the Marathon event bus.",
+		Long: `Tail the Marathon event bus.
+
+Events are printed as they are received, one per line. With --json, each event
+is printed as a single line of JSON (NDJSON), as sent by Marathon.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			return marathonEvents(ctx, client, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVar(&opts.types, "type", nil, "Only show events of the given types, e.g. status_update_event. Can be repeated.")
+	flags.StringVar(&opts.app, "app", "", "Only show events related to the apps and pods matching the given glob pattern, e.g. /prod/*.")
+	flags.BoolVar(&opts.jsonOut, "json", false, "Print the events as newline-delimited JSON.")
+	return cmd
+}
+
+func marathonEvents(ctx api.Context, client *marathon.Client, opts eventsOptions) error {
+	var appGlob glob.Glob
+	if opts.app != "" {
+		var err error
+		appGlob, err = glob.Compile(marathon.NormalizeAppID(opts.app))
+		if err != nil {
+			return fmt.Errorf("invalid app pattern '%s': %s", opts.app, err)
+		}
+	}
+
+	events, unsubscribe, err := client.Events(opts.types...)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	for event := range events {
+		if appGlob != nil && !matchesEventApp(event, appGlob) {
+			continue
+		}
+
+		if opts.jsonOut {
+			var line bytes.Buffer
+			if err := json.Compact(&line, event.Data); err != nil {
+				continue
+			}
+			line.WriteByte('\n')
+			if _, err := ctx.Out().Write(line.Bytes()); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(ctx.Out(), "%s %s %s\n", event.Timestamp(), event.Type, event.Summary())
+	}
+	return fmt.Errorf("the Marathon event stream was closed")
+}
+
+func matchesEventApp(event *marathon.Event, appGlob glob.Glob) bool {
+	for _, id := range event.AppIDs() {
+		if appGlob.Match(marathon.NormalizeAppID(id)) {
+			return true
+		}
+	}
+	return false
+}
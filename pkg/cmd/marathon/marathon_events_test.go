@@ -0,0 +1,54 @@
+package marathon
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const eventsStream = "event: status_update_event\n" +
+	`data: {"eventType":"status_update_event","timestamp":"2020-01-01T00:00:00.000Z","taskId":"web.1","taskStatus":"TASK_FAILED","appId":"/prod/web","host":"10.0.0.1","message":"exited"}` + "\n\n" +
+	"event: status_update_event\n" +
+	`data: {"eventType":"status_update_event","timestamp":"2020-01-01T00:00:01.000Z","taskId":"db.1","taskStatus":"TASK_RUNNING","appId":"/dev/db","host":"10.0.0.2"}` + "\n\n" +
+	"event: deployment_success\n" +
+	`data: {"eventType":"deployment_success","timestamp":"2020-01-01T00:00:02.000Z","id":"d1","plan":{"id":"d1","steps":[{"actions":[{"action":"ScaleApplication","app":"/prod/api"}]}]}}` + "\n\n"
+
+func newEventsServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/service/marathon/v2/events", r.URL.Path)
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		fmt.Fprint(w, eventsStream)
+	}))
+}
+
+func TestMarathonEvents(t *testing.T) {
+	ts := newEventsServer(t)
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = marathonEvents(ctx, client, eventsOptions{app: "prod/*"})
+	assert.EqualError(t, err, "the Marathon event stream was closed")
+	assert.Equal(t, "2020-01-01T00:00:00.000Z status_update_event task web.1 of /prod/web is TASK_FAILED on 10.0.0.1: exited\n"+
+		"2020-01-01T00:00:02.000Z deployment_success deployment d1 succeeded\n", out.String())
+}
+
+func TestMarathonEventsJSON(t *testing.T) {
+	ts := newEventsServer(t)
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = marathonEvents(ctx, client, eventsOptions{app: "/dev/db", jsonOut: true})
+	assert.EqualError(t, err, "the Marathon event stream was closed")
+	assert.Equal(t, `{"eventType":"status_update_event","timestamp":"2020-01-01T00:00:01.000Z","taskId":"db.1","taskStatus":"TASK_RUNNING","appId":"/dev/db","host":"10.0.0.2"}`+"\n", out.String())
+}
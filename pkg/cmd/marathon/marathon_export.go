@@ -0,0 +1,65 @@
+package marathon
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/spf13/cobra"
+)
+
+func newCmdMarathonExport(ctx api.Context) *cobra.Command {
+	var groupID string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export -o <dir>",
+		Short: "Export the definitions of all apps, pods and groups to a directory.",
+		Long: `Export the definitions of all apps, pods and groups to a directory.
+
+The directory layout mirrors the group hierarchy: each group is a directory
+holding its definition in _group.json and one JSON file per app and pod.
+Fields managed by Marathon, such as versions, running tasks or deployments,
+are left out. The directory can be restored with 'dcos marathon import'.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			return marathonExport(ctx, client, groupID, output)
+		},
+	}
+
+	cmd.Flags().StringVar(&groupID, "group", "/", "Only export this group.")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Directory to export the definitions to, it must be empty or not exist.")
+	cmd.MarkFlagRequired("output")
+
+	return cmd
+}
+
+func marathonExport(ctx api.Context, client *marathon.Client, groupID string, output string) error {
+	files, err := ioutil.ReadDir(output)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(files) > 0 {
+		return fmt.Errorf("directory %s is not empty", output)
+	}
+
+	group, err := client.RawGroup(groupID)
+	if err != nil {
+		return err
+	}
+
+	resources, err := marathon.ExportGroup(group, output)
+	if err != nil {
+		return err
+	}
+	for _, resource := range resources {
+		fmt.Fprintf(ctx.Out(), "Exported %s %s to %s\n", resource.Kind, resource.ID, resource.Source)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package marathon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarathonExportImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "marathon-export")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "backup")
+
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /service/marathon/v2/groups/prod":
+			w.Write([]byte(`{"id":"/prod","dependencies":["/infra"],"version":"2020-01-01T00:00:00.000Z",
+				"apps":[{"id":"/prod/web","cmd":"sleep 100","tasksRunning":1}],"pods":[],"groups":[]}`))
+		case "GET /service/marathon/v2/groups/":
+			w.Write([]byte(liveGroups))
+		case "POST /service/marathon/v2/groups":
+			var group map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&group))
+			assert.Equal(t, map[string]interface{}{"id": "/staging"}, group)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"deploymentId":"deployment-1","version":"2020-01-02T00:00:00.000Z"}`))
+		case "POST /service/marathon/v2/apps":
+			w.Header().Set("Marathon-Deployment-Id", "deployment-2")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		case "PUT /service/marathon/v2/groups/staging":
+			var changes map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&changes))
+			assert.Equal(t, map[string]interface{}{"dependencies": []interface{}{"/infra"}}, changes)
+			w.Write([]byte(`{"deploymentId":"deployment-3","version":"2020-01-02T00:00:00.000Z"}`))
+		default:
+			t.Fatalf("unexpected call to %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = marathonExport(ctx, client, "/prod", output)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Exported app /prod/web to "+filepath.Join(output, "web.json"))
+
+	err = marathonExport(ctx, client, "/prod", output)
+	assert.EqualError(t, err, "directory "+output+" is not empty")
+
+	// Import the export under another group to check that it is recreated.
+	require.NoError(t, ioutil.WriteFile(filepath.Join(output, "_group.json"), []byte(`{"id":"/staging","dependencies":["/infra"]}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(output, "web.json"), []byte(`{"id":"/staging/web","cmd":"sleep 100"}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(output, "old.json"), []byte(`{"id":"/prod/old","cmd":"sleep 100"}`), 0600))
+
+	out.Reset()
+	err = marathonImport(ctx, client, output, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "Created deployment deployment-1 to create group /staging\n"+
+		"Skipped app /prod/old, it already exists\n"+
+		"Created deployment deployment-2 to create app /staging/web\n"+
+		"Created deployment deployment-3 to set the dependencies of group /staging\n", out.String())
+}
+
+func TestMarathonImportWaitsForDependencies(t *testing.T) {
+	dir, err := ioutil.TempDir("", "marathon-import")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "_group.json"), []byte(`{"id":"/staging"}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "db.json"), []byte(`{"id":"/staging/db","cmd":"sleep 100"}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "web.json"), []byte(`{"id":"/staging/web","cmd":"sleep 100","dependencies":["db"]}`), 0600))
+
+	deployed := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /service/marathon/v2/events":
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-deployed
+			fmt.Fprint(w, "event: deployment_success\ndata: {\"eventType\":\"deployment_success\",\"id\":\"deployment-db\"}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		case "GET /service/marathon/v2/deployments":
+			w.Write([]byte(`[]`))
+		case "GET /service/marathon/v2/groups/":
+			w.Write([]byte(`{"id":"/","apps":[],"pods":[],"groups":[]}`))
+		case "POST /service/marathon/v2/groups":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"deploymentId":"deployment-group","version":"2020-01-02T00:00:00.000Z"}`))
+		case "POST /service/marathon/v2/apps":
+			var app map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&app))
+			if app["id"] == "/staging/db" {
+				close(deployed)
+				w.Header().Set("Marathon-Deployment-Id", "deployment-db")
+			} else {
+				w.Header().Set("Marathon-Deployment-Id", "deployment-web")
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		default:
+			t.Fatalf("unexpected call to %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = marathonImport(ctx, client, dir, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "Created deployment deployment-group to create group /staging\n"+
+		"Created deployment deployment-db to create app /staging/db\n"+
+		"Waiting for deployment deployment-db to finish\n"+
+		"Deployment deployment-db succeeded\n"+
+		"Created deployment deployment-web to create app /staging/web\n", out.String())
+}
@@ -0,0 +1,104 @@
+package marathon
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/spf13/cobra"
+)
+
+func newCmdMarathonImport(ctx api.Context) *cobra.Command {
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "import <dir>",
+		Short: "Create the apps, pods and groups exported to a directory.",
+		Long: `Create the apps, pods and groups exported to a directory.
+
+Groups are created first, then apps and pods after the ones they depend on.
+The deployments of the apps and pods others depend on are waited for before
+creating the next ones. Group dependencies are set once all apps and pods
+have been created. Apps, pods and groups which already exist are left
+untouched.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			return marathonImport(ctx, client, args[0], timeout)
+		},
+	}
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Maximum time to wait for each deployment others depend on, e.g. 10m (0 means no timeout).")
+	return cmd
+}
+
+func marathonImport(ctx api.Context, client *marathon.Client, dir string, timeout time.Duration) error {
+	resources, err := marathon.LoadExport(dir)
+	if err != nil {
+		return err
+	}
+
+	dependedOn := marathon.DependedOn(resources)
+	events, unsubscribe, err := client.SubscribeDeploymentEvents(marathon.WaitOptions{Wait: len(dependedOn) > 0, Timeout: timeout})
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	existing, err := client.ExistingResources()
+	if err != nil {
+		return err
+	}
+
+	// Group dependencies can only be set once the apps and pods they refer to exist.
+	var groupDependencies []marathon.Resource
+	for _, resource := range resources {
+		if kind, ok := existing[resource.ID]; ok {
+			if kind != resource.Kind {
+				return fmt.Errorf("cannot create %s '%s', a %s with the same ID already exists", resource.Kind, resource.ID, kind)
+			}
+			fmt.Fprintf(ctx.Out(), "Skipped %s %s, it already exists\n", resource.Kind, resource.ID)
+			continue
+		}
+
+		if resource.Kind == marathon.KindGroup {
+			if dependencies, ok := resource.Definition["dependencies"].([]interface{}); ok && len(dependencies) > 0 {
+				groupDependencies = append(groupDependencies, resource)
+			}
+			definition := make(map[string]interface{}, len(resource.Definition))
+			for key, value := range resource.Definition {
+				if key != "dependencies" {
+					definition[key] = value
+				}
+			}
+			resource.Definition = definition
+		}
+
+		step := marathon.PlanStep{Action: marathon.ActionCreate, Resource: resource}
+		deploymentID, err := client.ApplyStep(step, false)
+		if err != nil {
+			return fmt.Errorf("unable to create %s '%s': %s", resource.Kind, resource.ID, err)
+		}
+		fmt.Fprintf(ctx.Out(), "Created deployment %s to create %s %s\n", deploymentID, resource.Kind, resource.ID)
+
+		// The resources depending on this one are only created once it is deployed.
+		if dependedOn[resource.ID] {
+			if err := client.WaitForDeployment(ctx.Out(), events, deploymentID, timeout); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, group := range groupDependencies {
+		changes := map[string]interface{}{"dependencies": group.Definition["dependencies"]}
+		deploymentID, err := client.UpdateGroup(group.ID, changes, false)
+		if err != nil {
+			return fmt.Errorf("unable to set the dependencies of group '%s': %s", group.ID, err)
+		}
+		fmt.Fprintf(ctx.Out(), "Created deployment %s to set the dependencies of group %s\n", deploymentID, group.ID)
+	}
+	return nil
+}
@@ -19,7 +19,7 @@ func TestMarathonPingOnce(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	ctx := newContext(ts)
+	ctx, _ := newContext(ts)
 
 	client, err := marathon.NewClient(ctx)
 	assert.NoError(t, err)
@@ -43,7 +43,7 @@ func TestMarathonPing(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	ctx := newContext(ts)
+	ctx, _ := newContext(ts)
 
 	client, err := marathon.NewClient(ctx)
 	assert.NoError(t, err)
@@ -74,7 +74,7 @@ func TestMarathonPingError(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	ctx := newContext(ts)
+	ctx, _ := newContext(ts)
 
 	client, err := marathon.NewClient(ctx)
 	assert.NoError(t, err)
@@ -1,8 +1,14 @@
 package marathon
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-cli/pkg/cli"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
@@ -20,17 +26,53 @@ func newCmdMarathonPlugin(ctx api.Context) *cobra.Command {
 }
 
 func newCmdMarathonPluginList(ctx api.Context) *cobra.Command {
-	var json bool
+	var jsonOutput bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List plugins.",
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			return marathonPluginList(ctx, client, jsonOutput)
 		},
 	}
 
-	cmd.Flags().BoolVar(&json, "json", false, "Print JSON-formatted data.")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print JSON-formatted data.")
 
 	return cmd
 }
+
+func marathonPluginList(ctx api.Context, client *marathon.Client, jsonOutput bool) error {
+	plugins, err := client.Plugins()
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(ctx.Out())
+		enc.SetIndent("", "    ")
+		return enc.Encode(plugins)
+	}
+
+	items := make([][]string, 0, len(plugins.Plugins))
+	for _, plugin := range plugins.Plugins {
+		items = append(items, []string{
+			fmt.Sprint(plugin["id"]),
+			fmt.Sprint(plugin["implementation"]),
+			fmt.Sprint(plugin["plugin"]),
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i][0] < items[j][0]
+	})
+
+	table := cli.NewTable(ctx.Out(), []string{"ID", "IMPLEMENTATION", "PLUGIN"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.AppendBulk(items)
+	table.Render()
+	return nil
+}
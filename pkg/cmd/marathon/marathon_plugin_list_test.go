@@ -0,0 +1,33 @@
+package marathon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarathonPluginList(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/service/marathon/v2/plugins", r.URL.String())
+		assert.Equal(t, "GET", r.Method)
+		w.Write([]byte(`{"plugins":[
+			{"id":"teamAuth","implementation":"com.example.TeamAuth","plugin":"mesosphere.marathon.plugin.auth.Authorizer"},
+			{"id":"envVars","implementation":"com.example.EnvVars","plugin":"mesosphere.marathon.plugin.task.RunSpecTaskProcessor"}
+		]}`))
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, marathonPluginList(ctx, client, false))
+	expected := "     ID        IMPLEMENTATION                            PLUGIN                         \n" +
+		"  envVars   com.example.EnvVars   mesosphere.marathon.plugin.task.RunSpecTaskProcessor  \n" +
+		"  teamAuth  com.example.TeamAuth  mesosphere.marathon.plugin.auth.Authorizer            \n"
+	assert.Equal(t, expected, out.String())
+}
@@ -18,6 +18,7 @@ func NewCmdMarathonPod(ctx api.Context) *cobra.Command {
 		newCmdMarathonPodRemove(ctx),
 		newCmdMarathonPodShow(ctx),
 		newCmdMarathonPodUpdate(ctx),
+		newCmdMarathonPodValidate(ctx),
 	)
 
 	return cmd
@@ -1,18 +1,55 @@
 package pod
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonPodAdd(ctx api.Context) *cobra.Command {
+	var waitOpts marathon.WaitOptions
+
 	cmd := &cobra.Command{
-		Use:   "add",
+		Use:   "add [<pod-resource>]",
 		Short: "Add a pod.",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			if len(args) > 0 {
+				return marathonPodAdd(ctx, *client, args[0], waitOpts)
+			}
+			return marathonPodAdd(ctx, *client, "", waitOpts)
 		},
 	}
+	marathon.AddWaitFlags(cmd, &waitOpts)
 	return cmd
 }
+
+func marathonPodAdd(ctx api.Context, client marathon.Client, podFile string, waitOpts marathon.WaitOptions) error {
+	events, unsubscribe, err := client.SubscribeDeploymentEvents(waitOpts)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	deploymentID, err := client.AddPod(ctx, podFile)
+	if err != nil {
+		if err == marathon.ErrCannotReadPodDefinition {
+			return fmt.Errorf("can't read from resource: %s. Please check that it exists", podFile)
+		} else if syntaxError, ok := err.(*json.SyntaxError); ok {
+			return fmt.Errorf("error loading JSON: %s", syntaxError.Error())
+		}
+		return err
+	}
+	fmt.Fprintf(ctx.Out(), "Created deployment %s\n", deploymentID)
+	if !waitOpts.Wait {
+		return nil
+	}
+	return client.WaitForDeployment(ctx.Out(), events, deploymentID, waitOpts.Timeout)
+}
@@ -1,18 +1,42 @@
 package pod
 
 import (
+	"fmt"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	goMarathon "github.com/gambol99/go-marathon"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonPodKill(ctx api.Context) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "kill",
+		Use:   "kill <pod-id> <instance-id>...",
 		Short: "Kill one or more running pod instances.",
+		Args:  cobra.MinimumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			return marathonPodKill(ctx, *client, args[0], args[1:])
 		},
 	}
 	return cmd
 }
+
+func marathonPodKill(ctx api.Context, client marathon.Client, podID string, instanceIDs []string) error {
+	instances, err := client.API.DeletePodInstances(podID, instanceIDs)
+	if apiErr, ok := err.(*goMarathon.APIError); ok && apiErr.ErrCode == goMarathon.ErrCodeNotFound {
+		return fmt.Errorf(`pod '%s' does not exist`, marathon.NormalizeAppID(podID))
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, instance := range instances {
+		fmt.Fprintf(ctx.Out(), "Killed instance %s\n", instance.InstanceID.ID)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package pod
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	marathonmocks "github.com/dcos/dcos-core-cli/pkg/marathon/mocks"
+	goMarathon "github.com/gambol99/go-marathon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPodKill(t *testing.T) {
+	marathonMock := marathonmocks.MarathonMock{}
+	marathonMock.DeletePodInstancesFn = func(podID string, instanceIDs []string) ([]*goMarathon.PodInstance, error) {
+		assert.Equal(t, "/test-pod", podID)
+		assert.Equal(t, []string{"instance-1", "instance-2"}, instanceIDs)
+
+		instances := make([]*goMarathon.PodInstance, 0, len(instanceIDs))
+		for _, id := range instanceIDs {
+			instances = append(instances, &goMarathon.PodInstance{InstanceID: goMarathon.PodInstanceID{ID: id}})
+		}
+		return instances, nil
+	}
+
+	out := new(bytes.Buffer)
+	env := mock.NewEnvironment()
+	env.Out = out
+	ctx := mock.NewContext(env)
+
+	err := marathonPodKill(ctx, marathon.Client{API: &marathonMock}, "/test-pod", []string{"instance-1", "instance-2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, marathonMock.DeletePodInstancesInvocations)
+	assert.Equal(t, "Killed instance instance-1\nKilled instance instance-2\n", out.String())
+}
+
+func TestPodKillMissingPod(t *testing.T) {
+	marathonMock := marathonmocks.MarathonMock{}
+	marathonMock.DeletePodInstancesFn = func(string, []string) ([]*goMarathon.PodInstance, error) {
+		return nil, &goMarathon.APIError{ErrCode: goMarathon.ErrCodeNotFound}
+	}
+
+	ctx := mock.NewContext(nil)
+
+	err := marathonPodKill(ctx, marathon.Client{API: &marathonMock}, "test-pod", []string{"instance-1"})
+	assert.EqualError(t, err, "pod '/test-pod' does not exist")
+}
@@ -1,25 +1,139 @@
 package pod
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-cli/pkg/cli"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	m "github.com/gambol99/go-marathon"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
+const defaultTableValue = "---"
+
 func newCmdMarathonPodList(ctx api.Context) *cobra.Command {
-	var json bool
+	var jsonOutput bool
 	var quiet bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List the deployed pods.",
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			return podList(ctx, client, quiet, jsonOutput)
 		},
 	}
 
-	cmd.Flags().BoolVar(&json, "json", false, "Print JSON-formatted data.")
-	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print JSON-formatted data.")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Display IDs only.")
 
 	return cmd
 }
+
+func podList(ctx api.Context, client *marathon.Client, quiet bool, jsonOutput bool) error {
+	if jsonOutput {
+		pods, err := client.RawPods()
+		if err != nil {
+			return err
+		}
+		sort.Slice(pods, func(i, j int) bool {
+			return fmt.Sprint(pods[i]["id"]) < fmt.Sprint(pods[j]["id"])
+		})
+		enc := json.NewEncoder(ctx.Out())
+		enc.SetIndent("", "    ")
+		return enc.Encode(pods)
+	}
+
+	pods, err := client.Pods()
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].ID < pods[j].ID
+	})
+
+	if quiet {
+		for _, pod := range pods {
+			fmt.Fprintln(ctx.Out(), pod.ID)
+		}
+		return nil
+	}
+
+	queue, err := client.Queue()
+	if err != nil {
+		return err
+	}
+
+	tableHeader := []string{"ID+TASKS", "INSTANCES", "VERSION", "STATUS", "STATUS SINCE", "WAITING", "ROLE"}
+	table := cli.NewTable(ctx.Out(), tableHeader)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	for _, pod := range pods {
+		table.Append([]string{
+			formatIDAndContainers(pod),
+			strconv.Itoa(len(pod.Instances)),
+			formatVersion(pod),
+			string(pod.Status),
+			pod.StatusSince,
+			formatWaiting(pod, queue),
+			formatRole(pod),
+		})
+	}
+	table.Render()
+
+	return nil
+}
+
+func formatIDAndContainers(pod m.PodStatus) string {
+	if pod.Spec == nil {
+		return pod.ID
+	}
+
+	names := make([]string, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		names = append(names, container.Name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(pod.ID)
+	for _, name := range names {
+		b.WriteString("\n |-" + name)
+	}
+	return b.String()
+}
+
+func formatVersion(pod m.PodStatus) string {
+	if pod.Spec == nil || pod.Spec.Version == "" {
+		return defaultTableValue
+	}
+	return pod.Spec.Version
+}
+
+func formatWaiting(pod m.PodStatus, queue m.Queue) string {
+	for _, item := range queue.Items {
+		if item.Pod != nil && item.Pod.ID == pod.ID && item.Delay.Overdue {
+			return "true"
+		}
+	}
+	return "false"
+}
+
+func formatRole(pod m.PodStatus) string {
+	if pod.Spec == nil || pod.Spec.Role == nil {
+		return defaultTableValue
+	}
+	return *pod.Spec.Role
+}
@@ -0,0 +1,153 @@
+package pod
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	m "github.com/gambol99/go-marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPods() []m.PodStatus {
+	return []m.PodStatus{
+		{
+			ID: "/zeta",
+			Spec: &m.Pod{
+				ID:      "/zeta",
+				Version: "2020-01-01T00:00:00.000Z",
+				Containers: []*m.PodContainer{
+					{Name: "web"},
+					{Name: "sidecar"},
+				},
+			},
+			Status:      m.PodStateStable,
+			StatusSince: "2020-01-01T00:00:01.000Z",
+			Instances:   []*m.PodInstanceStatus{{}, {}},
+		},
+		{
+			ID: "/alpha",
+			Spec: &m.Pod{
+				ID:         "/alpha",
+				Version:    "2020-01-02T00:00:00.000Z",
+				Role:       strPointer("slave_public"),
+				Containers: []*m.PodContainer{{Name: "main"}},
+			},
+			Status:      m.PodStateDegraded,
+			StatusSince: "2020-01-02T00:00:01.000Z",
+			Instances:   []*m.PodInstanceStatus{{}},
+		},
+	}
+}
+
+func TestPodList(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch url := r.URL.String(); url {
+		case "/service/marathon/v2/pods/::status":
+			json.NewEncoder(w).Encode(testPods())
+		case "/service/marathon/v2/queue":
+			queue := m.Queue{
+				Items: []m.Item{
+					{
+						Pod:   &m.Pod{ID: "/alpha"},
+						Delay: m.Delay{Overdue: true},
+					},
+				},
+			}
+			json.NewEncoder(w).Encode(queue)
+		default:
+			t.Fatalf("unexpected call to endpoint %s", url)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = podList(ctx, client, false, false)
+	assert.NoError(t, err)
+	expected := "   ID+TASKS   INSTANCES          VERSION            STATUS         STATUS SINCE        WAITING      ROLE      \n" +
+		"  /alpha      1          2020-01-02T00:00:00.000Z  DEGRADED  2020-01-02T00:00:01.000Z  true     slave_public  \n" +
+		"   |-main                                                                                                     \n" +
+		"  /zeta       2          2020-01-01T00:00:00.000Z  STABLE    2020-01-01T00:00:01.000Z  false    ---           \n" +
+		"   |-sidecar                                                                                                  \n" +
+		"   |-web                                                                                                      \n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestPodListQuiet(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/service/marathon/v2/pods/::status", r.URL.String())
+		json.NewEncoder(w).Encode(testPods())
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = podList(ctx, client, true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "/alpha\n/zeta\n", out.String())
+}
+
+func TestPodListJSON(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(testPods())
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = podList(ctx, client, false, true)
+	assert.NoError(t, err)
+
+	var pods []m.PodStatus
+	require.NoError(t, json.Unmarshal(out.Bytes(), &pods))
+	require.Len(t, pods, 2)
+	assert.Equal(t, "/alpha", pods[0].ID)
+	assert.Equal(t, "/zeta", pods[1].ID)
+}
+
+func TestPodListJSONUnknownFields(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":"/web","status":"STABLE","terminationHistory":[{"instanceID":"web.instance-1"}]}]`))
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = podList(ctx, client, false, true)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id":"/web","status":"STABLE","terminationHistory":[{"instanceID":"web.instance-1"}]}]`, out.String())
+}
+
+func strPointer(s string) *string {
+	return &s
+}
+
+func newContext(ts *httptest.Server) (*mock.Context, *bytes.Buffer) {
+	out := new(bytes.Buffer)
+	env := mock.NewEnvironment()
+	env.Out = out
+	ctx := mock.NewContext(env)
+	cluster := config.NewCluster(nil)
+	cluster.SetURL(ts.URL)
+	ctx.SetCluster(cluster)
+	return ctx, out
+}
@@ -1,23 +1,56 @@
 package pod
 
 import (
+	"fmt"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	goMarathon "github.com/gambol99/go-marathon"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonPodRemove(ctx api.Context) *cobra.Command {
 	var force bool
+	var waitOpts marathon.WaitOptions
 
 	cmd := &cobra.Command{
-		Use:   "remove",
+		Use:   "remove <pod-id>",
 		Short: "Remove a pod.",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			return marathonPodRemove(ctx, *client, args[0], force, waitOpts)
 		},
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Disable checks in Marathon during updates.")
+	marathon.AddWaitFlags(cmd, &waitOpts)
 
 	return cmd
 }
+
+func marathonPodRemove(ctx api.Context, client marathon.Client, podID string, force bool, waitOpts marathon.WaitOptions) error {
+	events, unsubscribe, err := client.SubscribeDeploymentEvents(waitOpts)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	deploymentID, err := client.API.DeletePod(podID, force)
+	if apiErr, ok := err.(*goMarathon.APIError); ok && apiErr.ErrCode == goMarathon.ErrCodeNotFound {
+		return fmt.Errorf(`pod '%s' does not exist`, marathon.NormalizeAppID(podID))
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ctx.Out(), "Created deployment %s\n", deploymentID.DeploymentID)
+	if !waitOpts.Wait {
+		return nil
+	}
+	return client.WaitForDeployment(ctx.Out(), events, deploymentID.DeploymentID, waitOpts.Timeout)
+}
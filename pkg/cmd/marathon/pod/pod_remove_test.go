@@ -0,0 +1,53 @@
+package pod
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	marathonmocks "github.com/dcos/dcos-core-cli/pkg/marathon/mocks"
+	goMarathon "github.com/gambol99/go-marathon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPodRemove(t *testing.T) {
+	tests := []struct {
+		name    string
+		force   bool
+		err     error
+		wantErr string
+	}{
+		{name: "remove", force: false},
+		{name: "remove with force", force: true},
+		{
+			name:    "missing pod",
+			err:     &goMarathon.APIError{ErrCode: goMarathon.ErrCodeNotFound},
+			wantErr: "pod '/test-pod' does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			marathonMock := marathonmocks.MarathonMock{}
+			marathonMock.DeletePodFn = func(podID string, force bool) (*goMarathon.DeploymentID, error) {
+				assert.Equal(t, "test-pod", podID)
+				assert.Equal(t, tt.force, force)
+				return &goMarathon.DeploymentID{DeploymentID: "5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43"}, tt.err
+			}
+
+			var out bytes.Buffer
+			env := mock.NewEnvironment()
+			env.Out = &out
+
+			err := marathonPodRemove(mock.NewContext(env), marathon.Client{API: &marathonMock}, "test-pod", tt.force, marathon.WaitOptions{})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Created deployment 5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43\n", out.String())
+			}
+			assert.Equal(t, 1, marathonMock.DeletePodInvocations)
+		})
+	}
+}
@@ -1,18 +1,37 @@
 package pod
 
 import (
+	"encoding/json"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonPodShow(ctx api.Context) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "show",
+		Use:   "show <pod-id>",
 		Short: "Display detailed information for a specific pod.",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			return marathonPodShow(ctx, *client, args[0])
 		},
 	}
 	return cmd
 }
+
+func marathonPodShow(ctx api.Context, client marathon.Client, podID string) error {
+	pod, err := client.RawPod(podID)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(ctx.Out())
+	enc.SetIndent("", "    ")
+	return enc.Encode(pod)
+}
@@ -0,0 +1,34 @@
+package pod
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPodShow(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/service/marathon/v2/pods/web":
+			w.Write([]byte(`{"id":"/web","containers":[{"name":"main"}],"legacySharedCgroups":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, marathonPodShow(ctx, *client, "web"))
+	assert.JSONEq(t, `{"id":"/web","containers":[{"name":"main"}],"legacySharedCgroups":true}`, out.String())
+
+	err = marathonPodShow(ctx, *client, "missing")
+	assert.EqualError(t, err, "pod '/missing' does not exist")
+}
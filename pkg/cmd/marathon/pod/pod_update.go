@@ -1,23 +1,59 @@
 package pod
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonPodUpdate(ctx api.Context) *cobra.Command {
 	var force bool
+	var waitOpts marathon.WaitOptions
 
 	cmd := &cobra.Command{
-		Use:   "update",
+		Use:   "update <pod-id> [<pod-resource>]",
 		Short: "Update a pod.",
+		Args:  cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			if len(args) > 1 {
+				return marathonPodUpdate(ctx, *client, args[0], args[1], force, waitOpts)
+			}
+			return marathonPodUpdate(ctx, *client, args[0], "", force, waitOpts)
 		},
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Disable checks in Marathon during updates.")
+	marathon.AddWaitFlags(cmd, &waitOpts)
 
 	return cmd
 }
+
+func marathonPodUpdate(ctx api.Context, client marathon.Client, podID string, podFile string, force bool, waitOpts marathon.WaitOptions) error {
+	events, unsubscribe, err := client.SubscribeDeploymentEvents(waitOpts)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	deploymentID, err := client.UpdatePod(ctx, podID, podFile, force)
+	if err != nil {
+		if err == marathon.ErrCannotReadPodDefinition {
+			return fmt.Errorf("can't read from resource: %s. Please check that it exists", podFile)
+		} else if syntaxError, ok := err.(*json.SyntaxError); ok {
+			return fmt.Errorf("error loading JSON: %s", syntaxError.Error())
+		}
+		return err
+	}
+	fmt.Fprintf(ctx.Out(), "Created deployment %s\n", deploymentID)
+	if !waitOpts.Wait {
+		return nil
+	}
+	return client.WaitForDeployment(ctx.Out(), events, deploymentID, waitOpts.Timeout)
+}
@@ -0,0 +1,53 @@
+package pod
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/spf13/cobra"
+)
+
+func newCmdMarathonPodValidate(ctx api.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [<pod-resource>]",
+		Short: "Validate a pod definition without deploying it.",
+		Long: `Validate a pod definition without deploying it.
+
+The definition is checked locally against the Marathon pod schema, no
+cluster is needed. If no resource is given, the definition is read from
+stdin.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return podValidate(ctx, args[0])
+			}
+			return podValidate(ctx, "")
+		},
+	}
+	return cmd
+}
+
+func podValidate(ctx api.Context, podFile string) error {
+	pod, err := marathon.LoadDefinition(ctx, podFile, nil)
+	if err != nil {
+		if err == marathon.ErrCannotReadAppDefinition {
+			return fmt.Errorf("can't read from resource: %s. Please check that it exists", podFile)
+		} else if syntaxError, ok := err.(*json.SyntaxError); ok {
+			return fmt.Errorf("error loading JSON: %s", syntaxError.Error())
+		}
+		return err
+	}
+
+	errors := marathon.ValidatePod(pod)
+	if len(errors) == 0 {
+		fmt.Fprintln(ctx.Out(), "The pod definition is valid.")
+		return nil
+	}
+
+	for _, validationError := range errors {
+		fmt.Fprintln(ctx.Out(), validationError)
+	}
+	return fmt.Errorf("the pod definition has %d error(s)", len(errors))
+}
@@ -1,10 +1,18 @@
 package task
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/gobwas/glob"
 	"github.com/spf13/cobra"
 )
 
+const defaultTableValue = "---"
+
 func NewCmdMarathonTask(ctx api.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "task",
@@ -20,3 +28,75 @@ func NewCmdMarathonTask(ctx api.Context) *cobra.Command {
 
 	return cmd
 }
+
+// taskFilters are the criteria tasks must match to be listed.
+type taskFilters struct {
+	app    string
+	agent  string
+	health string
+	state  string
+}
+
+// filterTasks returns the tasks matching the given filters, sorted by app and task ID.
+func filterTasks(tasks []marathon.Task, filters taskFilters) ([]marathon.Task, error) {
+	var appGlob, agentGlob glob.Glob
+	var err error
+	if filters.app != "" {
+		appGlob, err = glob.Compile(marathon.NormalizeAppID(filters.app))
+		if err != nil {
+			return nil, fmt.Errorf("invalid app pattern '%s': %s", filters.app, err)
+		}
+	}
+	if filters.agent != "" {
+		agentGlob, err = glob.Compile(filters.agent)
+		if err != nil {
+			return nil, fmt.Errorf("invalid agent pattern '%s': %s", filters.agent, err)
+		}
+	}
+
+	health := strings.ToLower(filters.health)
+	switch health {
+	case "", "healthy", "unhealthy", "unknown":
+	default:
+		return nil, fmt.Errorf("invalid health '%s', expected healthy, unhealthy or unknown", filters.health)
+	}
+
+	state := strings.ToUpper(filters.state)
+	if state != "" && !strings.HasPrefix(state, "TASK_") {
+		state = "TASK_" + state
+	}
+
+	var result []marathon.Task
+	for _, task := range tasks {
+		if appGlob != nil && !appGlob.Match(marathon.NormalizeAppID(task.AppID)) {
+			continue
+		}
+		if agentGlob != nil && !agentGlob.Match(task.Host) {
+			continue
+		}
+		if health != "" && task.Health() != health {
+			continue
+		}
+		if state != "" && task.State != state {
+			continue
+		}
+		result = append(result, task)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].AppID != result[j].AppID {
+			return result[i].AppID < result[j].AppID
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+func findTask(tasks []marathon.Task, taskID string) (*marathon.Task, bool) {
+	for i := range tasks {
+		if tasks[i].ID == taskID {
+			return &tasks[i], true
+		}
+	}
+	return nil, false
+}
@@ -1,27 +1,128 @@
 package task
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-cli/pkg/cli"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/gobwas/glob"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonTaskKill(ctx api.Context) *cobra.Command {
 	var scale bool
 	var wipe bool
-	var json bool
+	var jsonOutput bool
 
 	cmd := &cobra.Command{
-		Use:   "kill",
+		Use:   "kill <task-id>...",
 		Short: "Kill one or more tasks.",
+		Long: `Kill one or more tasks.
+
+Task IDs can be glob patterns, e.g. 'prod_web.*' kills all the tasks of the /prod/web app.
+A summary of the killed tasks is printed once they are killed.`,
+		Args: cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if scale && wipe {
+				return errors.New("the flags 'scale' and 'wipe' cannot be used together")
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			return taskKill(ctx, client, args, scale, wipe, jsonOutput)
 		},
 	}
 
 	cmd.Flags().BoolVar(&scale, "scale", false, "Scale the app down after performing the the operation.")
 	cmd.Flags().BoolVar(&wipe, "wipe", false, "Wipe persistent data.")
-	cmd.Flags().BoolVar(&json, "json", false, "Print JSON-formatted data.")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print JSON-formatted data.")
 
 	return cmd
 }
+
+func taskKill(ctx api.Context, client *marathon.Client, patterns []string, scale bool, wipe bool, jsonOutput bool) error {
+	tasks, err := client.Tasks()
+	if err != nil {
+		return err
+	}
+
+	var targets []marathon.Task
+	var unmatched []string
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		g, err := glob.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid task pattern '%s': %s", pattern, err)
+		}
+
+		matched := false
+		for _, task := range tasks {
+			if g.Match(task.ID) {
+				matched = true
+				if !seen[task.ID] {
+					seen[task.ID] = true
+					targets = append(targets, task)
+				}
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, pattern)
+		}
+	}
+
+	if len(targets) == 0 {
+		return fmt.Errorf("no tasks match %s", strings.Join(patterns, ", "))
+	}
+
+	ids := make([]string, 0, len(targets))
+	for _, task := range targets {
+		ids = append(ids, task.ID)
+	}
+	killed, deploymentID, err := client.KillTasksByID(ids, scale, wipe)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(ctx.Out())
+		enc.SetIndent("", "    ")
+		if scale {
+			return enc.Encode(map[string]string{"deploymentId": deploymentID})
+		}
+		if killed == nil {
+			killed = []marathon.Task{}
+		}
+		return enc.Encode(killed)
+	}
+
+	table := cli.NewTable(ctx.Out(), []string{"ID", "APP", "HOST", "RESULT"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	for _, task := range targets {
+		result := "not killed"
+		if _, ok := findTask(killed, task.ID); ok {
+			result = "killed"
+		} else if scale {
+			result = "killed and scaled down"
+		}
+		table.Append([]string{task.ID, task.AppID, task.Host, result})
+	}
+	for _, pattern := range unmatched {
+		table.Append([]string{pattern, defaultTableValue, defaultTableValue, "not found"})
+	}
+	table.Render()
+
+	if scale {
+		fmt.Fprintf(ctx.Out(), "Created deployment %s\n", deploymentID)
+	}
+	return nil
+}
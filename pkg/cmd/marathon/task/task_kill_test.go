@@ -0,0 +1,68 @@
+package task
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskKill(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/service/marathon/v2/tasks":
+			w.Write([]byte(tasksFixture))
+		case "/service/marathon/v2/tasks/delete":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "", r.URL.RawQuery)
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"ids":["prod_web.1","prod_web.2"]}`, string(body))
+			w.Write([]byte(`{"tasks":[{"id":"prod_web.1","appId":"/prod/web","host":"10.0.0.1"}]}`))
+		default:
+			t.Fatalf("unexpected request %s", r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = taskKill(ctx, client, []string{"prod_web.*", "prod_web.1", "staging_*"}, false, false, false)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "prod_web.1  /prod/web  10.0.0.1  killed")
+	assert.Contains(t, out.String(), "prod_web.2  /prod/web  10.0.0.2  not killed")
+	assert.Contains(t, out.String(), "staging_*   ---        ---       not found")
+}
+
+func TestTaskKillScale(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/service/marathon/v2/tasks":
+			w.Write([]byte(tasksFixture))
+		case "/service/marathon/v2/tasks/delete":
+			assert.Equal(t, "scale=true", r.URL.RawQuery)
+			w.Write([]byte(`{"deploymentId":"d1","version":"2020-01-01T00:00:00.000Z"}`))
+		default:
+			t.Fatalf("unexpected request %s", r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = taskKill(ctx, client, []string{"dev_*"}, true, false, false)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "killed and scaled down")
+	assert.Contains(t, out.String(), "Created deployment d1\n")
+
+	err = taskKill(ctx, client, []string{"staging_*"}, true, false, false)
+	assert.EqualError(t, err, "no tasks match staging_*")
+}
@@ -1,25 +1,108 @@
 package task
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-cli/pkg/cli"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonTaskList(ctx api.Context) *cobra.Command {
-	var json bool
+	var jsonOutput bool
 	var quiet bool
+	var filters taskFilters
 
 	cmd := &cobra.Command{
-		Use:   "list",
+		Use:   "list [<app-id>]",
 		Short: "List all tasks.",
+		Long: `List all tasks.
+
+The app ID can be a glob pattern, e.g. /prod/* lists the tasks of all the apps in the /prod group.`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			if len(args) == 1 {
+				filters.app = args[0]
+			}
+
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			return taskList(ctx, client, filters, quiet, jsonOutput)
 		},
 	}
 
-	cmd.Flags().BoolVar(&json, "json", false, "Print JSON-formatted data.")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print JSON-formatted data.")
 	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Display IDs only.")
+	cmd.Flags().StringVar(&filters.agent, "agent", "", "Only list the tasks running on the agents whose hostname matches this glob pattern.")
+	cmd.Flags().StringVar(&filters.health, "health", "", "Only list the tasks with this health: healthy, unhealthy or unknown.")
+	cmd.Flags().StringVar(&filters.state, "state", "", "Only list the tasks in this state, e.g. TASK_RUNNING or running.")
 
 	return cmd
 }
+
+func taskList(ctx api.Context, client *marathon.Client, filters taskFilters, quiet bool, jsonOutput bool) error {
+	tasks, err := client.Tasks()
+	if err != nil {
+		return err
+	}
+
+	tasks, err = filterTasks(tasks, filters)
+	if err != nil {
+		return err
+	}
+
+	if quiet {
+		for _, task := range tasks {
+			fmt.Fprintln(ctx.Out(), task.ID)
+		}
+		return nil
+	}
+
+	if jsonOutput {
+		if tasks == nil {
+			tasks = []marathon.Task{}
+		}
+		enc := json.NewEncoder(ctx.Out())
+		enc.SetIndent("", "    ")
+		return enc.Encode(tasks)
+	}
+
+	table := cli.NewTable(ctx.Out(), []string{"APP", "HEALTHY", "STATE", "STARTED", "HOST", "ID"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	for _, task := range tasks {
+		table.Append([]string{
+			task.AppID,
+			formatHealthy(task),
+			task.State,
+			formatOrDefault(task.StartedAt),
+			task.Host,
+			task.ID,
+		})
+	}
+	table.Render()
+	return nil
+}
+
+func formatHealthy(task marathon.Task) string {
+	switch task.Health() {
+	case "healthy":
+		return "True"
+	case "unhealthy":
+		return "False"
+	default:
+		return defaultTableValue
+	}
+}
+
+func formatOrDefault(value string) string {
+	if value == "" {
+		return defaultTableValue
+	}
+	return value
+}
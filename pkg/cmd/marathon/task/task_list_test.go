@@ -0,0 +1,94 @@
+package task
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tasksFixture = `{"tasks":[
+	{"id":"prod_web.1","appId":"/prod/web","host":"10.0.0.1","state":"TASK_RUNNING","startedAt":"2020-01-01T00:00:00.000Z","healthCheckResults":[{"alive":true}],"region":"us-east"},
+	{"id":"prod_web.2","appId":"/prod/web","host":"10.0.0.2","state":"TASK_RUNNING","startedAt":"2020-01-01T00:00:00.000Z","healthCheckResults":[{"alive":false}]},
+	{"id":"dev_db.1","appId":"/dev/db","host":"10.0.0.1","state":"TASK_STAGING"}
+]}`
+
+func newContext(ts *httptest.Server) (*mock.Context, *bytes.Buffer) {
+	out := new(bytes.Buffer)
+	env := mock.NewEnvironment()
+	env.Out = out
+	ctx := mock.NewContext(env)
+	cluster := config.NewCluster(nil)
+	cluster.SetURL(ts.URL)
+	ctx.SetCluster(cluster)
+	return ctx, out
+}
+
+func newTasksServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/service/marathon/v2/tasks", r.URL.Path)
+		w.Write([]byte(tasksFixture))
+	}))
+}
+
+func TestTaskListFilters(t *testing.T) {
+	ts := newTasksServer(t)
+	defer ts.Close()
+
+	fixtures := []struct {
+		filters taskFilters
+		ids     string
+	}{
+		{taskFilters{}, "dev_db.1\nprod_web.1\nprod_web.2\n"},
+		{taskFilters{app: "prod/*"}, "prod_web.1\nprod_web.2\n"},
+		{taskFilters{agent: "10.0.0.1"}, "dev_db.1\nprod_web.1\n"},
+		{taskFilters{health: "unhealthy"}, "prod_web.2\n"},
+		{taskFilters{health: "unknown"}, "dev_db.1\n"},
+		{taskFilters{state: "running", agent: "*.2"}, "prod_web.2\n"},
+	}
+
+	for _, fixture := range fixtures {
+		ctx, out := newContext(ts)
+		client, err := marathon.NewClient(ctx)
+		require.NoError(t, err)
+
+		err = taskList(ctx, client, fixture.filters, true, false)
+		require.NoError(t, err)
+		assert.Equal(t, fixture.ids, out.String())
+	}
+}
+
+func TestTaskListInvalidHealth(t *testing.T) {
+	ts := newTasksServer(t)
+	defer ts.Close()
+
+	ctx, _ := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = taskList(ctx, client, taskFilters{health: "sick"}, false, false)
+	assert.EqualError(t, err, "invalid health 'sick', expected healthy, unhealthy or unknown")
+}
+
+func TestTaskShow(t *testing.T) {
+	ts := newTasksServer(t)
+	defer ts.Close()
+
+	ctx, out := newContext(ts)
+	client, err := marathon.NewClient(ctx)
+	require.NoError(t, err)
+
+	err = taskShow(ctx, client, "prod_web.1")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"prod_web.1","appId":"/prod/web","host":"10.0.0.1","state":"TASK_RUNNING",
+		"startedAt":"2020-01-01T00:00:00.000Z","healthCheckResults":[{"alive":true}],"region":"us-east"}`, out.String())
+
+	err = taskShow(ctx, client, "prod_web.3")
+	assert.EqualError(t, err, "task 'prod_web.3' does not exist")
+}
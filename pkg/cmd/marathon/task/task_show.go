@@ -1,18 +1,43 @@
 package task
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
 func newCmdMarathonTaskShow(ctx api.Context) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "show",
+		Use:   "show <task-id>",
 		Short: "List a specific task.",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			return taskShow(ctx, client, args[0])
 		},
 	}
 	return cmd
 }
+
+func taskShow(ctx api.Context, client *marathon.Client, taskID string) error {
+	tasks, err := client.Tasks()
+	if err != nil {
+		return err
+	}
+
+	task, ok := findTask(tasks, taskID)
+	if !ok {
+		return fmt.Errorf("task '%s' does not exist", taskID)
+	}
+
+	enc := json.NewEncoder(ctx.Out())
+	enc.SetIndent("", "    ")
+	return enc.Encode(task)
+}
@@ -1,8 +1,11 @@
 package task
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/dcos/dcos-cli/api"
-	"github.com/dcos/dcos-core-cli/pkg/cmd/marathon/python"
+	"github.com/dcos/dcos-core-cli/pkg/marathon"
 	"github.com/spf13/cobra"
 )
 
@@ -10,10 +13,16 @@ func newCmdMarathonTaskStop(ctx api.Context) *cobra.Command {
 	var wipe bool
 
 	cmd := &cobra.Command{
-		Use:   "stop",
+		Use:   "stop <task-id>",
 		Short: "Stop a task.",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return python.InvokePythonCLI(ctx)
+			client, err := marathon.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			return taskStop(ctx, client, args[0], wipe)
 		},
 	}
 
@@ -21,3 +30,19 @@ func newCmdMarathonTaskStop(ctx api.Context) *cobra.Command {
 
 	return cmd
 }
+
+func taskStop(ctx api.Context, client *marathon.Client, taskID string, wipe bool) error {
+	killed, _, err := client.KillTasksByID([]string{taskID}, false, wipe)
+	if err != nil {
+		return err
+	}
+
+	task, ok := findTask(killed, taskID)
+	if !ok {
+		return fmt.Errorf("task '%s' does not exist", taskID)
+	}
+
+	enc := json.NewEncoder(ctx.Out())
+	enc.SetIndent("", "    ")
+	return enc.Encode(task)
+}
@@ -13,7 +13,7 @@ import (
 func newCmdNodeLog(ctx api.Context) *cobra.Command {
 	var component, mesosID, output string
 	var filters []string
-	var all, follow, leader bool
+	var all, follow, leader, noReconnect bool
 	var lines int
 
 	cmd := &cobra.Command{
@@ -80,10 +80,11 @@ func newCmdNodeLog(ctx api.Context) *cobra.Command {
 			client := logs.NewClient(pluginutil.HTTPClient(""), ctx.Out())
 
 			opts := logs.Options{
-				Filters: filters,
-				Follow:  follow,
-				Format:  output,
-				Skip:    -1 * lines,
+				Filters:     filters,
+				Follow:      follow,
+				Format:      output,
+				Skip:        -1 * lines,
+				NoReconnect: noReconnect,
 			}
 
 			return client.PrintComponent(route, service, opts)
@@ -97,6 +98,7 @@ func newCmdNodeLog(ctx api.Context) *cobra.Command {
 	cmd.Flags().BoolVar(&leader, "leader", false, "The leading master")
 	cmd.Flags().IntVar(&lines, "lines", 10, "Print the N last lines")
 	cmd.Flags().StringVar(&mesosID, "mesos-id", "", "The agent ID of a node")
+	cmd.Flags().BoolVar(&noReconnect, "no-reconnect", false, "Stop following the log when its stream is interrupted instead of resuming it")
 	cmd.Flags().StringVarP(&output, "output", "o", "short", "Format log message output")
 	return cmd
 }
@@ -19,7 +19,7 @@ type ipsResult struct {
 }
 
 func newCmdServiceLog(ctx api.Context) *cobra.Command {
-	var follow bool
+	var follow, noReconnect bool
 	var lines int
 	var file, output, sshConfig string
 
@@ -58,9 +58,10 @@ func newCmdServiceLog(ctx api.Context) *cobra.Command {
 
 				logClient := logs.NewClient(pluginutil.HTTPClient(url), ctx.Out())
 				opts := logs.Options{
-					Follow: follow,
-					Format: output,
-					Skip:   -1 * lines,
+					Follow:      follow,
+					Format:      output,
+					Skip:        -1 * lines,
+					NoReconnect: noReconnect,
 				}
 				return logClient.PrintComponent("/leader/mesos", "/dcos-marathon.service", opts)
 			}
@@ -75,20 +76,21 @@ func newCmdServiceLog(ctx api.Context) *cobra.Command {
 			}
 
 			logClient := logs.NewClient(pluginutil.HTTPClient(""), ctx.Out())
-			if output != "short" {
-				output = "short"
-				ctx.Logger().Info(`Task logs don't support output options. Defaulting to "short"...`)
-			}
 			opts := logs.Options{
-				Follow: follow,
-				Format: output,
-				Skip:   -1 * lines,
+				Follow:      follow,
+				Skip:        -1 * lines,
+				NoReconnect: noReconnect,
+			}
+			// Task logs are printed as is unless an output is explicitly given.
+			if cmd.Flags().Changed("output") {
+				opts.Format = output
 			}
 			return logClient.PrintTask(taskID, file, opts)
 		},
 	}
 
 	cmd.Flags().BoolVar(&follow, "follow", false, "Dynamically update the log")
+	cmd.Flags().BoolVar(&noReconnect, "no-reconnect", false, "Stop following the log when its stream is interrupted instead of resuming it")
 	cmd.Flags().IntVar(&lines, "lines", 10, "Print the N last lines. 10 is the default")
 	cmd.Flags().StringVarP(&output, "output", "o", "short", "Format log message output")
 	cmd.Flags().StringVar(&sshConfig, "ssh-config-file", "", "Path to SSH configuration file. This is deprecated")
@@ -1,8 +1,12 @@
 package task
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"sync"
 
 	"github.com/dcos/dcos-cli/api"
 	"github.com/dcos/dcos-cli/pkg/cli"
@@ -45,12 +49,17 @@ func NewCommand(ctx api.Context) *cobra.Command {
 
 	cmd.AddCommand(
 		newCmdTaskAttach(ctx),
+		newCmdTaskCat(ctx),
+		newCmdTaskCp(ctx),
 		newCmdTaskDownload(ctx),
 		newCmdTaskExec(ctx),
 		newCmdTaskList(ctx),
 		newCmdTaskLog(ctx),
 		newCmdTaskLs(ctx),
 		newCmdTaskMetrics(ctx),
+		newCmdTaskPortForward(ctx),
+		newCmdTaskReplay(ctx),
+		newCmdTaskTail(ctx),
 	)
 	return cmd
 }
@@ -180,7 +189,42 @@ func mesosHTTPClient(ctx api.Context, agentID string) (*httpcli.Client, error) {
 	return httpClient, nil
 }
 
-func newTaskIO(ctx api.Context, id string, interactive bool, tty bool, user string) (*mesos.TaskIO, error) {
+func newTaskIO(ctx api.Context, id string, interactive bool, tty bool, user string, recorder *mesos.Recorder) (*mesos.TaskIO, error) {
+	return newTaskIOWithOpts(ctx, id, mesos.TaskIOOpts{
+		Stdin:       ctx.Input(),
+		Stdout:      ctx.Out(),
+		Stderr:      ctx.ErrOut(),
+		Interactive: interactive,
+		TTY:         tty,
+		User:        user,
+		Recorder:    recorder,
+	})
+}
+
+// runRecorded calls run with a recorder writing to the file record, or with a nil recorder
+// when record is empty. The file is closed before returning, os.Exit skipping deferred calls.
+func runRecorded(record string, run func(*mesos.Recorder) (int, error)) (int, error) {
+	if record == "" {
+		return run(nil)
+	}
+
+	f, err := os.Create(record)
+	if err != nil {
+		return 0, err
+	}
+	recorder := mesos.NewRecorder(f)
+	exitCode, err := run(recorder)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = recorder.Err()
+	}
+	return exitCode, err
+}
+
+// newTaskIOWithOpts creates a TaskIO for the running task matching id.
+func newTaskIOWithOpts(ctx api.Context, id string, opts mesos.TaskIOOpts) (*mesos.TaskIO, error) {
 	filters := taskFilters{
 		Active: true,
 		ID:     id,
@@ -190,7 +234,12 @@ func newTaskIO(ctx api.Context, id string, interactive bool, tty bool, user stri
 	if err != nil {
 		return nil, err
 	}
+	return newTaskIOForTask(ctx, task, opts)
+}
 
+// newTaskIOForTask creates a TaskIO for the given running task, the sender
+// and the logger of opts are set to talk to the agent of the task.
+func newTaskIOForTask(ctx api.Context, task *mesos.Task, opts mesos.TaskIOOpts) (*mesos.TaskIO, error) {
 	httpClient, err := mesosHTTPClient(ctx, task.SlaveID)
 	if err != nil {
 		return nil, err
@@ -206,16 +255,8 @@ func newTaskIO(ctx api.Context, id string, interactive bool, tty bool, user stri
 		}
 	}
 
-	opts := mesos.TaskIOOpts{
-		Stdin:       ctx.Input(),
-		Stdout:      ctx.Out(),
-		Stderr:      ctx.ErrOut(),
-		Interactive: interactive,
-		TTY:         tty,
-		User:        user,
-		Sender:      httpagent.NewSender(httpClient.Send),
-		Logger:      pluginutil.Logger(),
-	}
+	opts.Sender = httpagent.NewSender(httpClient.Send)
+	opts.Logger = pluginutil.Logger()
 
 	if escapeSequenceEnv, ok := ctx.EnvLookup("DCOS_TASK_ESCAPE_SEQUENCE"); ok {
 		opts.EscapeSequence, err = term.ToBytes(escapeSequenceEnv)
@@ -225,3 +266,50 @@ func newTaskIO(ctx api.Context, id string, interactive bool, tty bool, user stri
 	}
 	return mesos.NewTaskIO(containerID, opts)
 }
+
+// prefixWriter writes complete lines to out, each of them prefixed with prefix.
+// Writers sharing the same mutex can write to out concurrently without mixing their lines.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	i := bytes.LastIndexByte(w.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	if err := w.writeLines(w.buf[:i+1]); err != nil {
+		return 0, err
+	}
+	w.buf = w.buf[i+1:]
+	return len(p), nil
+}
+
+// Flush writes the last line if it isn't terminated by a newline.
+func (w *prefixWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.writeLines(append(w.buf, '\n'))
+	w.buf = nil
+	return err
+}
+
+func (w *prefixWriter) writeLines(lines []byte) error {
+	var out bytes.Buffer
+	for _, line := range bytes.SplitAfter(lines, []byte("\n")) {
+		if len(line) > 0 {
+			out.WriteString(w.prefix)
+			out.Write(line)
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := w.out.Write(out.Bytes())
+	return err
+}
@@ -4,23 +4,26 @@ import (
 	"os"
 
 	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/mesos"
 	"github.com/spf13/cobra"
 )
 
 func newCmdTaskAttach(ctx api.Context) *cobra.Command {
 	var noStdin bool
+	var record string
 
 	cmd := &cobra.Command{
 		Use:   "attach <task>",
 		Short: "Attach the CLI to the stdio of an already running task",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			taskIO, err := newTaskIO(ctx, args[0], !noStdin, true, "")
-			if err != nil {
-				return err
-			}
-
-			exitCode, err := taskIO.Attach()
+			exitCode, err := runRecorded(record, func(recorder *mesos.Recorder) (int, error) {
+				taskIO, err := newTaskIO(ctx, args[0], !noStdin, true, "", recorder)
+				if err != nil {
+					return 0, err
+				}
+				return taskIO.Attach()
+			})
 			if err != nil {
 				return err
 			}
@@ -30,6 +33,7 @@ func newCmdTaskAttach(ctx api.Context) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&noStdin, "no-stdin", false, "Don't attach the stdin of the CLI to the task")
+	cmd.Flags().StringVar(&record, "record", "", "Record the session to an asciicast file, which can be played with 'dcos task replay'")
 
 	return cmd
 }
@@ -0,0 +1,151 @@
+package task
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sync"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/mesos"
+	"github.com/dcos/dcos-core-cli/pkg/pluginutil"
+	"github.com/spf13/cobra"
+)
+
+// readPageSize is the maximum number of bytes requested at once from the files API.
+const readPageSize = 512 * 1024
+
+// newCmdTaskCat prints a file from the sandbox of tasks.
+func newCmdTaskCat(ctx api.Context) *cobra.Command {
+	var all, completed bool
+
+	cmd := &cobra.Command{
+		Use:   "cat <task> <path>",
+		Short: "Print a file from the sandbox of tasks",
+		Long: `Print a file from the sandbox of tasks
+
+The <path> argument is relative to the sandbox of the task. When several tasks
+match <task>, each line is prefixed with the ID of its task.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tasks, err := findTasks(ctx, taskFilters{
+				Active:    !completed,
+				Completed: all || completed,
+				ID:        args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			c := mesos.NewClient(pluginutil.HTTPClient(""))
+			return readTaskFiles(ctx, c, tasks, args[1], func(f *sandboxFile, w io.Writer) error {
+				_, err := f.copyFrom(0, w)
+				return err
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "Include completed and in-progress tasks")
+	cmd.Flags().BoolVar(&completed, "completed", false, "Only include completed tasks")
+	return cmd
+}
+
+// readTaskFiles calls read with the file at filePath in the sandbox of each task. When there are several
+// tasks, they are read concurrently and each line written by read is prefixed with the ID of its task.
+func readTaskFiles(ctx api.Context, c *mesos.Client, tasks []mesos.Task, filePath string,
+	read func(*sandboxFile, io.Writer) error) error {
+	if len(tasks) == 1 {
+		f, err := openSandboxFile(c, &tasks[0], filePath)
+		if err != nil {
+			return err
+		}
+		return read(f, ctx.Out())
+	}
+
+	var outMu, errMu sync.Mutex
+	var failed bool
+	var wg sync.WaitGroup
+	for i := range tasks {
+		wg.Add(1)
+		go func(task *mesos.Task) {
+			defer wg.Done()
+
+			out := &prefixWriter{mu: &outMu, out: ctx.Out(), prefix: task.ID + " | "}
+			f, err := openSandboxFile(c, task, filePath)
+			if err == nil {
+				err = read(f, out)
+			}
+			out.Flush()
+
+			if err != nil {
+				errMu.Lock()
+				failed = true
+				fmt.Fprintf(ctx.ErrOut(), "Error: %s: %v\n", task.ID, err)
+				errMu.Unlock()
+			}
+		}(&tasks[i])
+	}
+	wg.Wait()
+
+	if failed {
+		return fmt.Errorf("could not read '%s' in all matched tasks", filePath)
+	}
+	return nil
+}
+
+// sandboxFile reads a file in the sandbox of a task through the files API of its agent.
+type sandboxFile struct {
+	c     *mesos.Client
+	agent string
+	path  string
+}
+
+// openSandboxFile returns the file at filePath, relative to the sandbox of task.
+func openSandboxFile(c *mesos.Client, task *mesos.Task, filePath string) (*sandboxFile, error) {
+	sandboxPath, err := taskSandboxPath(c, task)
+	if err != nil {
+		return nil, err
+	}
+	return &sandboxFile{c: c, agent: task.SlaveID, path: path.Join(sandboxPath, filePath)}, nil
+}
+
+// size returns the current size of the file.
+func (f *sandboxFile) size() (int64, error) {
+	data, err := f.c.ReadFile(f.agent, f.path, -1, 0)
+	if err != nil {
+		return 0, err
+	}
+	return data.Offset, nil
+}
+
+// copyFrom writes the file from offset to its current end to w and returns the offset reached.
+func (f *sandboxFile) copyFrom(offset int64, w io.Writer) (int64, error) {
+	for {
+		data, err := f.c.ReadFile(f.agent, f.path, offset, readPageSize)
+		if err != nil {
+			return offset, err
+		}
+		if len(data.Data) == 0 {
+			return offset, nil
+		}
+		if _, err := io.WriteString(w, data.Data); err != nil {
+			return offset, err
+		}
+		offset += int64(len(data.Data))
+	}
+}
+
+// read returns the length bytes at offset, or fewer if the end of the file is reached.
+func (f *sandboxFile) read(offset int64, length int64) ([]byte, error) {
+	var buf []byte
+	for int64(len(buf)) < length {
+		data, err := f.c.ReadFile(f.agent, f.path, offset+int64(len(buf)), length-int64(len(buf)))
+		if err != nil {
+			return nil, err
+		}
+		if len(data.Data) == 0 {
+			break
+		}
+		buf = append(buf, data.Data...)
+	}
+	return buf, nil
+}
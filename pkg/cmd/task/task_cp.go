@@ -0,0 +1,357 @@
+package task
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/mesos"
+	"github.com/spf13/cobra"
+)
+
+const cpDescription = `Copy files and directories between a running task and the local filesystem
+
+One of the arguments must be a path in a task, written as <task>:<path>,
+where <path> is an absolute path in the container of the task. The other
+argument is a local path. When the destination is an existing directory,
+the source is copied into it, otherwise it is copied to the destination.
+
+Files are streamed as a tar archive through a process launched next to the
+task, the 'tar' command must thus be available in its container. File modes
+and modification times are preserved.`
+
+// extractScript extracts a tar archive read from stdin to the destination $1, the archive
+// entries being rooted at $2. It behaves like cp: when $1 is an existing directory the
+// archive is extracted into it, otherwise its root entry is renamed to $1.
+const extractScript = `dest="$1"; name="$2"
+if [ -d "$dest" ]; then
+	exec tar -xpf - -C "$dest"
+fi
+parent="$(dirname "$dest")"
+mkdir -p "$parent" || exit 1
+tmp="$(mktemp -d "$parent/.dcos-cp.XXXXXX")" || exit 1
+tar -xpf - -C "$tmp" && rm -rf "$dest" && mv "$tmp/$name" "$dest"
+status=$?
+rm -rf "$tmp"
+exit $status`
+
+// newCmdTaskCp copies files between a task and the local filesystem.
+func newCmdTaskCp(ctx api.Context) *cobra.Command {
+	var user string
+
+	cmd := &cobra.Command{
+		Use:   "cp <src> <dst>",
+		Short: "Copy files and directories between a running task and the local filesystem",
+		Long:  cpDescription,
+		Example: `  dcos task cp ./nginx.conf web:/etc/nginx/nginx.conf
+  dcos task cp web:/var/log/nginx ./logs`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			srcTask, srcPath, srcRemote := splitTaskPath(args[0])
+			dstTask, dstPath, dstRemote := splitTaskPath(args[1])
+
+			switch {
+			case srcRemote && dstRemote:
+				return errors.New("copying files between two tasks is not supported")
+			case srcRemote:
+				return copyFromTask(ctx, srcTask, srcPath, dstPath, user)
+			case dstRemote:
+				return copyToTask(ctx, srcPath, dstTask, dstPath, user)
+			default:
+				return errors.New("one of the arguments must be a path in a task, as <task>:<path>")
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&user, "user", "u", "", "Run the copy in the task as the given user")
+	return cmd
+}
+
+// splitTaskPath splits an argument of the form <task>:<path>. Arguments without a colon,
+// or whose part before the colon looks like a local path or a Windows drive, are local paths.
+func splitTaskPath(arg string) (string, string, bool) {
+	i := strings.Index(arg, ":")
+	if i <= 1 || strings.ContainsAny(arg[:i], `/\`) {
+		return "", arg, false
+	}
+	return arg[:i], arg[i+1:], true
+}
+
+// remoteBase returns the name of the file or directory pointed to by an absolute path in a task.
+func remoteBase(remotePath string) (string, error) {
+	if !path.IsAbs(remotePath) {
+		return "", fmt.Errorf("the path in the task must be absolute: '%s'", remotePath)
+	}
+	name := path.Base(remotePath)
+	if name == "/" {
+		return "", errors.New("cannot copy the root directory of a task")
+	}
+	return name, nil
+}
+
+func copyToTask(ctx api.Context, src string, taskID string, dst string, user string) error {
+	if _, err := remoteBase(dst); err != nil {
+		return err
+	}
+	if _, err := os.Lstat(src); err != nil {
+		return err
+	}
+	name := filepath.Base(src)
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeArchive(pw, src, name))
+	}()
+	// Unblock the archive writer if the copy stops before the whole archive is read.
+	defer pr.Close()
+
+	var stderr bytes.Buffer
+	taskIO, err := newTaskIOWithOpts(ctx, taskID, mesos.TaskIOOpts{
+		Stdin:       pr,
+		Stdout:      ioutil.Discard,
+		Stderr:      &stderr,
+		Interactive: true,
+		User:        user,
+	})
+	if err != nil {
+		return err
+	}
+
+	exitCode, err := taskIO.Exec("sh", "-c", extractScript, "sh", path.Clean(dst), name)
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return remoteCopyError(src, taskID+":"+dst, exitCode, stderr.String())
+	}
+	return nil
+}
+
+func copyFromTask(ctx api.Context, taskID string, src string, dst string, user string) error {
+	name, err := remoteBase(src)
+	if err != nil {
+		return err
+	}
+
+	target := dst
+	if info, err := os.Stat(dst); err == nil && info.IsDir() {
+		target = filepath.Join(dst, name)
+	}
+
+	pr, pw := io.Pipe()
+	extracted := make(chan error, 1)
+	go func() {
+		err := extractArchive(pr, name, target)
+		// Drain the archive so that the trailing padding doesn't block the remote tar.
+		io.Copy(ioutil.Discard, pr)
+		extracted <- err
+	}()
+
+	var stderr bytes.Buffer
+	taskIO, err := newTaskIOWithOpts(ctx, taskID, mesos.TaskIOOpts{
+		Stdout: pw,
+		Stderr: &stderr,
+		User:   user,
+	})
+	if err != nil {
+		pw.Close()
+		return err
+	}
+
+	exitCode, err := taskIO.Exec("tar", "-cf", "-", "-C", path.Dir(path.Clean(src)), name)
+	pw.Close()
+	extractErr := <-extracted
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return remoteCopyError(taskID+":"+src, dst, exitCode, stderr.String())
+	}
+	return extractErr
+}
+
+func remoteCopyError(src string, dst string, exitCode int, stderr string) error {
+	if stderr = strings.TrimSpace(stderr); stderr != "" {
+		return fmt.Errorf("unable to copy %s to %s (exit code %d): %s", src, dst, exitCode, stderr)
+	}
+	return fmt.Errorf("unable to copy %s to %s (exit code %d)", src, dst, exitCode)
+}
+
+// writeArchive writes a tar archive of the file or directory src to w, with its entries rooted at name.
+func writeArchive(w io.Writer, src string, name string) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(src, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(name, filepath.ToSlash(rel))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		// The files are owned by the user running the copy in the task, not by the local user.
+		header.Uid, header.Gid, header.Uname, header.Gname = 0, 0, "", ""
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+type extractedDir struct {
+	path string
+	mode os.FileMode
+}
+
+// extractArchive extracts a tar archive whose entries are rooted at name to target.
+// Entries outside of name or leading through a symlink are rejected, file modes and modification
+// times are preserved.
+func extractArchive(r io.Reader, name string, target string) error {
+	// The modes of directories are set once extracted, a read-only directory couldn't be filled otherwise.
+	var dirs []extractedDir
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		entry := path.Clean(header.Name)
+		if entry != name && !strings.HasPrefix(entry, name+"/") {
+			return fmt.Errorf("unexpected entry '%s' in archive", header.Name)
+		}
+		file := filepath.Join(target, filepath.FromSlash(strings.TrimPrefix(entry, name)))
+		mode := header.FileInfo().Mode()
+
+		// A previous entry could have been a symlink to any local directory,
+		// only the symlink itself may be replaced, never written through.
+		leaf := file
+		if header.Typeflag == tar.TypeSymlink {
+			leaf = filepath.Dir(file)
+		}
+		if err := checkNoSymlink(target, leaf, header.Name); err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(file, 0755); err != nil {
+				return err
+			}
+			dirs = append(dirs, extractedDir{path: file, mode: mode.Perm()})
+		case tar.TypeReg, tar.TypeRegA:
+			if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+				return err
+			}
+			if err := extractFile(tr, file, mode.Perm()); err != nil {
+				return err
+			}
+			if err := os.Chtimes(file, header.ModTime, header.ModTime); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+				return err
+			}
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if err := os.Symlink(header.Linkname, file); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := os.Chmod(dirs[i].path, dirs[i].mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkNoSymlink returns an error when file or one of its parents below target is a symlink.
+func checkNoSymlink(target string, file string, entry string) error {
+	rel, err := filepath.Rel(target, file)
+	if err != nil {
+		return err
+	}
+	if rel == "." {
+		return nil
+	}
+
+	dir := target
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		dir = filepath.Join(dir, part)
+		info, err := os.Lstat(dir)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("unexpected entry '%s' in archive, it leads through a symlink", entry)
+		}
+	}
+	return nil
+}
+
+func extractFile(r io.Reader, file string, perm os.FileMode) error {
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// The mode passed to OpenFile is subject to the umask and isn't applied to existing files.
+	return os.Chmod(file, perm)
+}
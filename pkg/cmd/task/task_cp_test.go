@@ -0,0 +1,109 @@
+package task
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitTaskPath(t *testing.T) {
+	tests := []struct {
+		arg    string
+		task   string
+		path   string
+		remote bool
+	}{
+		{"web:/etc/nginx.conf", "web", "/etc/nginx.conf", true},
+		{"web.1a2b:/tmp", "web.1a2b", "/tmp", true},
+		{"./nginx.conf", "", "./nginx.conf", false},
+		{"./dir:with:colons", "", "./dir:with:colons", false},
+		{`C:\Users\me\nginx.conf`, "", `C:\Users\me\nginx.conf`, false},
+	}
+
+	for _, tt := range tests {
+		task, path, remote := splitTaskPath(tt.arg)
+		assert.Equal(t, tt.task, task, tt.arg)
+		assert.Equal(t, tt.path, path, tt.arg)
+		assert.Equal(t, tt.remote, remote, tt.arg)
+	}
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	src, err := ioutil.TempDir("", "dcos-task-cp-src")
+	require.NoError(t, err)
+	defer os.RemoveAll(src)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "conf", "bin"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "conf", "app.conf"), []byte("port=80\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "conf", "bin", "run.sh"), []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, os.Chmod(filepath.Join(src, "conf", "bin"), 0555))
+	defer os.Chmod(filepath.Join(src, "conf", "bin"), 0755)
+
+	var archive bytes.Buffer
+	require.NoError(t, writeArchive(&archive, filepath.Join(src, "conf"), "conf"))
+
+	dst, err := ioutil.TempDir("", "dcos-task-cp-dst")
+	require.NoError(t, err)
+	defer os.RemoveAll(dst)
+	target := filepath.Join(dst, "copy")
+	require.NoError(t, extractArchive(&archive, "conf", target))
+	defer os.Chmod(filepath.Join(target, "bin"), 0755)
+
+	data, err := ioutil.ReadFile(filepath.Join(target, "app.conf"))
+	require.NoError(t, err)
+	assert.Equal(t, "port=80\n", string(data))
+
+	for file, mode := range map[string]os.FileMode{
+		"app.conf":   0600,
+		"bin":        0555,
+		"bin/run.sh": 0755,
+	} {
+		info, err := os.Stat(filepath.Join(target, filepath.FromSlash(file)))
+		require.NoError(t, err)
+		assert.Equal(t, mode, info.Mode().Perm(), file)
+	}
+}
+
+func TestExtractArchiveRejectsOutsideEntries(t *testing.T) {
+	src, err := ioutil.TempDir("", "dcos-task-cp-src")
+	require.NoError(t, err)
+	defer os.RemoveAll(src)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "passwd"), []byte("root"), 0644))
+
+	var archive bytes.Buffer
+	require.NoError(t, writeArchive(&archive, filepath.Join(src, "passwd"), "../passwd"))
+
+	err = extractArchive(&archive, "passwd", filepath.Join(src, "copy"))
+	assert.EqualError(t, err, "unexpected entry '../passwd' in archive")
+}
+
+func TestExtractArchiveRejectsSymlinkedParents(t *testing.T) {
+	outside, err := ioutil.TempDir("", "dcos-task-cp-outside")
+	require.NoError(t, err)
+	defer os.RemoveAll(outside)
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "conf/", Typeflag: tar.TypeDir, Mode: 0755}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "conf/link", Typeflag: tar.TypeSymlink, Linkname: outside}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "conf/link/.bashrc", Typeflag: tar.TypeReg, Mode: 0644, Size: 4}))
+	_, err = tw.Write([]byte("evil"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	dst, err := ioutil.TempDir("", "dcos-task-cp-dst")
+	require.NoError(t, err)
+	defer os.RemoveAll(dst)
+
+	err = extractArchive(&archive, "conf", filepath.Join(dst, "copy"))
+	assert.EqualError(t, err, "unexpected entry 'conf/link/.bashrc' in archive, it leads through a symlink")
+
+	_, err = os.Lstat(filepath.Join(outside, ".bashrc"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -1,31 +1,61 @@
 package task
 
 import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/dcos/dcos-cli/api"
 	"github.com/dcos/dcos-core-cli/pkg/mesos"
 	"github.com/dcos/dcos-core-cli/pkg/pluginutil"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"github.com/vbauerster/mpb"
+	"github.com/vbauerster/mpb/decor"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 const description = `Download files from the sandbox of a given task
 
 The <path> argument can specify a pattern for a single or multiple files.
-If no <path> is provided, the entire sandbox will be downloaded.`
+If no <path> is provided, the entire sandbox will be downloaded.
+
+Files are streamed to disk, at most --parallel at a time. A file which already
+exists locally and is smaller than its remote counterpart is resumed from its
+current size, a file of the same size is skipped.
+
+With --archive, the files are written to a gzipped tar archive instead, one
+after the other.`
 
 // newCmdTaskDownload downloads files from a tasks sandbox.
 func newCmdTaskDownload(ctx api.Context) *cobra.Command {
 	var targetDir string
+	var archive string
+	var parallel int
 
 	cmd := &cobra.Command{
 		Use:   "download <task-id> [<path>]",
 		Short: "Download files from the sandbox of a given task",
 		Long:  description,
 		Args:  cobra.RangeArgs(1, 2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if archive != "" && cmd.Flags().Changed("target-dir") {
+				return errors.New("the flags 'archive' and 'target-dir' cannot be used together")
+			}
+			if parallel < 1 {
+				return errors.New("the flag 'parallel' must be greater than 0")
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			filters := taskFilters{
 				Active:    true,
@@ -38,20 +68,8 @@ func newCmdTaskDownload(ctx api.Context) *cobra.Command {
 				return err
 			}
 
-			containerID := task.Statuses[0].ContainerStatus.ContainerID.Value
-
-			executorID := task.ID
-			if task.ExecutorID != "" {
-				executorID = task.ExecutorID
-			}
-
 			c := mesos.NewClient(pluginutil.HTTPClient(""))
-			paths, err := c.Debug(task.SlaveID)
-			if err != nil {
-				return err
-			}
-
-			path, err := getPath(paths, task.FrameworkID, executorID, containerID)
+			sandboxPath, err := taskSandboxPath(c, task)
 			if err != nil {
 				return err
 			}
@@ -61,7 +79,19 @@ func newCmdTaskDownload(ctx api.Context) *cobra.Command {
 				pattern = args[1]
 			}
 
-			return download(c, ctx, task.SlaveID, path, targetDir, pattern)
+			files, err := listDownloads(c, task.SlaveID, sandboxPath, pattern)
+			if err != nil {
+				return err
+			}
+
+			progress := newDownloadProgress(ctx.ErrOut(), files)
+			if archive != "" {
+				err = downloadArchive(c, ctx.Fs(), task.SlaveID, files, archive, progress)
+			} else {
+				err = download(c, ctx, task.SlaveID, files, targetDir, parallel, progress)
+			}
+			progress.wait()
+			return err
 		},
 	}
 
@@ -70,9 +100,27 @@ func newCmdTaskDownload(ctx api.Context) *cobra.Command {
 		targetDir = dir
 	}
 	cmd.Flags().StringVar(&targetDir, "target-dir", targetDir, "Target directory of the download. Defaults to $PWD")
+	cmd.Flags().StringVar(&archive, "archive", "", "Write the files to a gzipped tar archive, e.g. out.tar.gz, instead of a directory")
+	cmd.Flags().IntVar(&parallel, "parallel", 4, "Maximum number of files to download at a time")
 	return cmd
 }
 
+// taskSandboxPath returns the path of the sandbox of a task on its agent.
+func taskSandboxPath(c *mesos.Client, task *mesos.Task) (string, error) {
+	containerID := task.Statuses[0].ContainerStatus.ContainerID.Value
+
+	executorID := task.ID
+	if task.ExecutorID != "" {
+		executorID = task.ExecutorID
+	}
+
+	paths, err := c.Debug(task.SlaveID)
+	if err != nil {
+		return "", err
+	}
+	return getPath(paths, task.FrameworkID, executorID, containerID)
+}
+
 func getPath(paths map[string]string, framework, executor, container string) (string, error) {
 	for k, v := range paths {
 		if strings.Contains(k, "/frameworks/"+framework+"/executors/"+executor+"/runs/"+container) {
@@ -82,92 +130,307 @@ func getPath(paths map[string]string, framework, executor, container string) (st
 	return "", fmt.Errorf("unable to find task")
 }
 
-func download(c *mesos.Client, ctx api.Context, agentID, agentPath, targetDir, pattern string) error {
-	if err := ctx.Fs().MkdirAll(targetDir, 0755); err != nil {
-		return err
-	}
+// downloadFile is a file or directory to download, name is its slash-separated path relative to the download root.
+type downloadFile struct {
+	path  string
+	name  string
+	dir   bool
+	size  int64
+	mode  os.FileMode
+	mtime time.Time
+}
 
-	parent := parentPath(agentPath, pattern)
-	base := basePath(pattern)
-	files, err := c.Browse(agentID, parent)
+// listDownloads returns the files matching pattern in the sandbox at agentPath, the content of
+// matching directories included. Directories are listed before the files they contain.
+func listDownloads(c *mesos.Client, agentID, agentPath, pattern string) ([]downloadFile, error) {
+	files, err := c.Browse(agentID, parentPath(agentPath, pattern))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	errors := make(chan error, len(files))
-	var matchCounter int
+	base := basePath(pattern)
+	var downloads []downloadFile
 	for _, file := range files {
-		matched, err := matchFile(base, filepath.Base(file.Path))
+		matched, err := matchFile(base, path.Base(file.Path))
 		if err != nil {
-			return fmt.Errorf("'%s' as pattern not supported: %v", pattern, err)
+			return nil, fmt.Errorf("'%s' as pattern not supported: %v", pattern, err)
 		}
-
 		if !matched {
-			errors <- nil
-			matchCounter++
 			continue
 		}
+		if downloads, err = appendDownload(c, agentID, downloads, file, path.Base(file.Path)); err != nil {
+			return nil, err
+		}
+	}
 
-		// TODO(rgo3): source out closure to separate func with config struct
-		go func(f mesos.File) {
-			if strings.HasPrefix(f.Mode, "d") {
-				err = download(c, ctx, agentID, f.Path, filepath.Join(targetDir, filepath.Base(f.Path)), "/")
-				if err != nil {
-					errors <- err
-					return
-				}
-			} else {
-				b, err := c.Download(agentID, f.Path)
-				if err != nil {
-					errors <- fmt.Errorf("could not fetch file '%s': %v", filepath.Base(f.Path), err)
-					return
-				}
-
-				out, err := ctx.Fs().Create(filepath.Join(targetDir, filepath.Base(f.Path)))
-				if err != nil {
-					errors <- fmt.Errorf("unable to create file '%s'", filepath.Base(f.Path))
-					return
-				}
-				defer out.Close()
-				_, err = out.Write(b)
-				if err != nil {
-					errors <- fmt.Errorf("unable to write to file '%s'", filepath.Base(f.Path))
-					return
-				}
-			}
-			errors <- nil
-		}(file)
+	if len(downloads) == 0 {
+		return nil, fmt.Errorf("no file or directory matched '%s'", pattern)
 	}
+	return downloads, nil
+}
 
-	if matchCounter == len(files) {
-		return fmt.Errorf("no file or directory matched '%s'", pattern)
+func appendDownload(c *mesos.Client, agentID string, downloads []downloadFile, file mesos.File, name string) ([]downloadFile, error) {
+	download := downloadFile{
+		path:  file.Path,
+		name:  name,
+		dir:   strings.HasPrefix(file.Mode, "d"),
+		size:  int64(file.Size),
+		mode:  parseFileMode(file.Mode),
+		mtime: time.Unix(int64(file.MTime), 0),
+	}
+	downloads = append(downloads, download)
+	if !download.dir {
+		return downloads, nil
 	}
 
-	var failed bool
-	for range files {
-		err := <-errors
+	files, err := c.Browse(agentID, file.Path)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		downloads, err = appendDownload(c, agentID, downloads, f, path.Join(name, path.Base(f.Path)))
 		if err != nil {
-			failed = true
-			fmt.Fprintf(ctx.ErrOut(), "Error: %v\n", err)
+			return nil, err
 		}
 	}
+	return downloads, nil
+}
+
+// parseFileMode parses the permissions of a mode listed by the Mesos files API, e.g. "-rw-r--r--".
+func parseFileMode(mode string) os.FileMode {
+	var perm os.FileMode
+	if len(mode) != 10 {
+		return 0644
+	}
+	for i, c := range mode[1:] {
+		if c != '-' {
+			perm |= 1 << uint(8-i)
+		}
+	}
+	return perm
+}
+
+// download streams files to targetDir, at most parallel at a time.
+func download(c *mesos.Client, ctx api.Context, agentID string, files []downloadFile, targetDir string,
+	parallel int, progress *downloadProgress) error {
+	fs := ctx.Fs()
+	if err := fs.MkdirAll(targetDir, 0755); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.dir {
+			if err := fs.MkdirAll(filepath.Join(targetDir, filepath.FromSlash(file.name)), 0755); err != nil {
+				return err
+			}
+		}
+	}
+
+	errs := make(chan error, len(files))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for _, file := range files {
+		if file.dir {
+			continue
+		}
+
+		wg.Add(1)
+		go func(file downloadFile) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			target := filepath.Join(targetDir, filepath.FromSlash(file.name))
+			if err := downloadToFile(c, fs, agentID, file, target, progress); err != nil {
+				errs <- fmt.Errorf("could not download '%s': %v", file.name, err)
+			}
+		}(file)
+	}
+	wg.Wait()
+	close(errs)
+
+	var failed bool
+	for err := range errs {
+		failed = true
+		fmt.Fprintf(ctx.ErrOut(), "Error: %v\n", err)
+	}
 	if failed {
 		return fmt.Errorf("could not download all matched files")
 	}
-
 	return nil
 }
 
+// downloadToFile streams a file to target. A smaller local file is resumed and a file of the same size is skipped.
+func downloadToFile(c *mesos.Client, fs afero.Fs, agentID string, file downloadFile, target string,
+	progress *downloadProgress) error {
+	var offset int64
+	if info, err := fs.Stat(target); err == nil && info.Mode().IsRegular() {
+		if info.Size() == file.size {
+			progress.skip(file.size)
+			return nil
+		}
+		if info.Size() < file.size {
+			offset = info.Size()
+		}
+	}
+
+	body, offset, err := c.DownloadRange(agentID, file.path, offset)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if offset == 0 {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	}
+	out, err := fs.OpenFile(target, flags, 0644)
+	if err != nil {
+		return err
+	}
+
+	bar := progress.start(file, offset)
+	_, err = io.Copy(out, bar.proxyReader(body))
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	bar.done(err)
+	return err
+}
+
+// downloadArchive streams files one after the other to a gzipped tar archive.
+// A file which grows during the download is truncated to its listed size.
+func downloadArchive(c *mesos.Client, fs afero.Fs, agentID string, files []downloadFile, archive string,
+	progress *downloadProgress) error {
+	out, err := fs.Create(archive)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	gw := gzip.NewWriter(out)
+	tw := tar.NewWriter(gw)
+	for _, file := range files {
+		if err := archiveFile(c, tw, agentID, file, progress); err != nil {
+			return fmt.Errorf("could not download '%s': %v", file.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+func archiveFile(c *mesos.Client, tw *tar.Writer, agentID string, file downloadFile, progress *downloadProgress) error {
+	header := &tar.Header{
+		Name:    file.name,
+		Mode:    int64(file.mode),
+		ModTime: file.mtime,
+	}
+	if file.dir {
+		header.Typeflag = tar.TypeDir
+		header.Name += "/"
+		return tw.WriteHeader(header)
+	}
+	header.Typeflag = tar.TypeReg
+	header.Size = file.size
+
+	body, _, err := c.DownloadRange(agentID, file.path, 0)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	bar := progress.start(file, 0)
+	n, err := io.CopyN(tw, bar.proxyReader(body), file.size)
+	if err == io.EOF {
+		err = fmt.Errorf("the file shrank from %d to %d bytes during the download", file.size, n)
+	}
+	bar.done(err)
+	return err
+}
+
+// downloadProgress renders a progress bar for each file being downloaded and one for the whole download.
+// The bars are only rendered when out is a terminal.
+type downloadProgress struct {
+	progress *mpb.Progress
+	total    *mpb.Bar
+}
+
+func newDownloadProgress(out io.Writer, files []downloadFile) *downloadProgress {
+	if file, ok := out.(*os.File); !ok || !terminal.IsTerminal(int(file.Fd())) {
+		out = ioutil.Discard
+	}
+
+	var size int64
+	for _, file := range files {
+		size += file.size
+	}
+
+	progress := mpb.New(mpb.WithOutput(out))
+	total := progress.AddBar(size,
+		mpb.PrependDecorators(decor.Name("Total", decor.WCSyncSpaceR)),
+		mpb.AppendDecorators(decor.CountersKibiByte("% 6.1f / % 6.1f")),
+	)
+	return &downloadProgress{progress: progress, total: total}
+}
+
+// skip accounts for a file which doesn't need to be downloaded.
+func (p *downloadProgress) skip(size int64) {
+	p.total.IncrBy(int(size))
+}
+
+// start adds the bar of a file whose download resumes at offset.
+func (p *downloadProgress) start(file downloadFile, offset int64) *fileProgress {
+	bar := p.progress.AddBar(file.size,
+		mpb.PrependDecorators(decor.Name(file.name, decor.WCSyncSpaceR)),
+		mpb.AppendDecorators(decor.CountersKibiByte("% 6.1f / % 6.1f")),
+		mpb.BarRemoveOnComplete(),
+	)
+	bar.IncrBy(int(offset))
+	p.total.IncrBy(int(offset))
+	return &fileProgress{parent: p, bar: bar}
+}
+
+// wait completes the total bar and waits for the bars to be rendered.
+func (p *downloadProgress) wait() {
+	p.total.SetTotal(p.total.Current(), true)
+	p.progress.Wait()
+}
+
+type fileProgress struct {
+	parent *downloadProgress
+	bar    *mpb.Bar
+}
+
+func (f *fileProgress) proxyReader(r io.Reader) io.Reader {
+	return f.parent.total.ProxyReader(f.bar.ProxyReader(r))
+}
+
+// done completes the bar, the size of the file may differ from the listed one as it can change during the download.
+func (f *fileProgress) done(err error) {
+	if err != nil {
+		f.parent.progress.Abort(f.bar, true)
+		return
+	}
+	f.bar.SetTotal(f.bar.Current(), true)
+}
+
 func parentPath(agentPath, pattern string) string {
-	parent := filepath.Dir(pattern)
+	parent := path.Dir(pattern)
 	if parent == "/" || parent == "." {
 		return agentPath
 	}
-	return filepath.Join(agentPath, parent)
+	return path.Join(agentPath, parent)
 }
 
 func basePath(pattern string) string {
-	base := filepath.Base(pattern)
+	base := path.Base(pattern)
 	if base == "/" || base == "." {
 		return ""
 	}
@@ -178,5 +441,5 @@ func matchFile(pattern, file string) (bool, error) {
 	if pattern == "" {
 		return true, nil
 	}
-	return filepath.Match(pattern, file)
+	return path.Match(pattern, file)
 }
@@ -0,0 +1,185 @@
+package task
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/dcos/dcos-core-cli/pkg/mesos"
+	"github.com/dcos/dcos-core-cli/pkg/pluginutil"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSandboxServer serves the files API of an agent with a sandbox made of the given files,
+// directories being the keys ending with a slash. It records the requested ranges by path.
+func newSandboxServer(t *testing.T, sandbox map[string]string) (*httptest.Server, map[string]string) {
+	var mu sync.Mutex
+	ranges := make(map[string]string)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filePath := r.URL.Query().Get("path")
+		switch r.URL.Path {
+		case "/agent/a1/files/browse":
+			var files []mesos.File
+			for name, content := range sandbox {
+				if path.Dir(strings.TrimSuffix(name, "/")) != filePath {
+					continue
+				}
+				file := mesos.File{Path: strings.TrimSuffix(name, "/"), Mode: "-rw-r-----", Size: float64(len(content))}
+				if strings.HasSuffix(name, "/") {
+					file.Mode = "drwxr-x---"
+				}
+				files = append(files, file)
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(files))
+		case "/agent/a1/files/download":
+			content, ok := sandbox[filePath]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			mu.Lock()
+			ranges[filePath] = r.Header.Get("Range")
+			mu.Unlock()
+
+			var offset int
+			if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &offset); err == nil {
+				w.WriteHeader(http.StatusPartialContent)
+			}
+			fmt.Fprint(w, content[offset:])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return ts, ranges
+}
+
+func TestDownload(t *testing.T) {
+	ts, ranges := newSandboxServer(t, map[string]string{
+		"/sandbox/stdout":      "hello world\n",
+		"/sandbox/stderr":      "oops\n",
+		"/sandbox/logs/":       "",
+		"/sandbox/logs/a.log":  "first\n",
+		"/sandbox/logs/b.log":  "second\n",
+		"/sandbox/config.yaml": "key: value\n",
+	})
+	defer ts.Close()
+
+	env := mock.NewEnvironment()
+	ctx := mock.NewContext(env)
+	fs := ctx.Fs()
+	require.NoError(t, afero.WriteFile(fs, "/out/stdout", []byte("hello "), 0644))
+	require.NoError(t, afero.WriteFile(fs, "/out/stderr", []byte("oops\n"), 0644))
+
+	c := mesos.NewClient(pluginutil.HTTPClient(ts.URL))
+	files, err := listDownloads(c, "a1", "/sandbox", "/")
+	require.NoError(t, err)
+
+	progress := newDownloadProgress(env.ErrOut, files)
+	err = download(c, ctx, "a1", files, "/out", 2, progress)
+	progress.wait()
+	require.NoError(t, err)
+
+	expected := map[string]string{
+		"/out/stdout":      "hello world\n",
+		"/out/stderr":      "oops\n",
+		"/out/logs/a.log":  "first\n",
+		"/out/logs/b.log":  "second\n",
+		"/out/config.yaml": "key: value\n",
+	}
+	for name, content := range expected {
+		data, err := afero.ReadFile(fs, name)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data), name)
+	}
+
+	assert.Equal(t, "bytes=6-", ranges["/sandbox/stdout"])
+	assert.NotContains(t, ranges, "/sandbox/stderr")
+	assert.Equal(t, "", ranges["/sandbox/config.yaml"])
+}
+
+func TestDownloadPattern(t *testing.T) {
+	ts, _ := newSandboxServer(t, map[string]string{
+		"/sandbox/logs/":      "",
+		"/sandbox/logs/a.log": "first\n",
+		"/sandbox/logs/b.txt": "second\n",
+	})
+	defer ts.Close()
+
+	c := mesos.NewClient(pluginutil.HTTPClient(ts.URL))
+	files, err := listDownloads(c, "a1", "/sandbox", "logs/*.log")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "a.log", files[0].name)
+	assert.Equal(t, "/sandbox/logs/a.log", files[0].path)
+
+	_, err = listDownloads(c, "a1", "/sandbox", "logs/*.gz")
+	assert.EqualError(t, err, "no file or directory matched 'logs/*.gz'")
+}
+
+func TestDownloadArchive(t *testing.T) {
+	ts, _ := newSandboxServer(t, map[string]string{
+		"/sandbox/stdout":     "hello world\n",
+		"/sandbox/logs/":      "",
+		"/sandbox/logs/a.log": "first\n",
+	})
+	defer ts.Close()
+
+	env := mock.NewEnvironment()
+	ctx := mock.NewContext(env)
+
+	c := mesos.NewClient(pluginutil.HTTPClient(ts.URL))
+	files, err := listDownloads(c, "a1", "/sandbox", "/")
+	require.NoError(t, err)
+
+	progress := newDownloadProgress(env.ErrOut, files)
+	err = downloadArchive(c, ctx.Fs(), "a1", files, "/out.tar.gz", progress)
+	progress.wait()
+	require.NoError(t, err)
+
+	f, err := ctx.Fs().Open("/out.tar.gz")
+	require.NoError(t, err)
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	entries := make(map[string]string)
+	modes := make(map[string]os.FileMode)
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			break
+		}
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		entries[header.Name] = string(data)
+		modes[header.Name] = os.FileMode(header.Mode)
+	}
+
+	assert.Equal(t, map[string]string{
+		"stdout":     "hello world\n",
+		"logs/":      "",
+		"logs/a.log": "first\n",
+	}, entries)
+	assert.Equal(t, os.FileMode(0640), modes["stdout"])
+	assert.Equal(t, os.FileMode(0750), modes["logs/"])
+}
+
+func TestParseFileMode(t *testing.T) {
+	assert.Equal(t, os.FileMode(0755), parseFileMode("drwxr-xr-x"))
+	assert.Equal(t, os.FileMode(0600), parseFileMode("-rw-------"))
+	assert.Equal(t, os.FileMode(0644), parseFileMode(""))
+}
@@ -1,27 +1,61 @@
 package task
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"sync"
 
 	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-cli/pkg/cli"
+	"github.com/dcos/dcos-core-cli/pkg/mesos"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
 func newCmdTaskExec(ctx api.Context) *cobra.Command {
 	var interactive, tty bool
 	var user string
+	var allMatching bool
+	var parallel int
+	var record string
 
 	cmd := &cobra.Command{
 		Use:   "exec [flags] <task> <cmd> [<args>...]",
 		Short: "Launch a process inside of a container for a task",
-		Args:  cobra.MinimumNArgs(2),
+		Long: `Launch a process inside of a container for a task
+
+With --all-matching, the process is launched in every running task matching
+<task>, at most --parallel at a time. Each line of output is prefixed with
+the ID of its task and the exit codes are summarized once all the processes
+have terminated.`,
+		Args: cobra.MinimumNArgs(2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if allMatching && (interactive || tty) {
+				return errors.New("the flag 'all-matching' cannot be used with 'interactive' or 'tty'")
+			}
+			if allMatching && record != "" {
+				return errors.New("the flags 'all-matching' and 'record' cannot be used together")
+			}
+			if parallel < 1 {
+				return errors.New("the flag 'parallel' must be greater than 0")
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			taskIO, err := newTaskIO(ctx, args[0], interactive, tty, user)
-			if err != nil {
-				return err
+			if allMatching {
+				return execAllMatching(ctx, args[0], args[1], args[2:], user, parallel)
 			}
 
-			exitCode, err := taskIO.Exec(args[1], args[2:]...)
+			exitCode, err := runRecorded(record, func(recorder *mesos.Recorder) (int, error) {
+				taskIO, err := newTaskIO(ctx, args[0], interactive, tty, user, recorder)
+				if err != nil {
+					return 0, err
+				}
+				return taskIO.Exec(args[1], args[2:]...)
+			})
 			if err != nil {
 				return err
 			}
@@ -33,7 +67,89 @@ func newCmdTaskExec(ctx api.Context) *cobra.Command {
 	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Attach a STDIN stream to the remote command for an interactive session")
 	cmd.Flags().BoolVarP(&tty, "tty", "t", false, "Attach a tty to the remote stream.")
 	cmd.Flags().StringVarP(&user, "user", "u", "", "Run as the given user")
+	cmd.Flags().BoolVar(&allMatching, "all-matching", false, "Launch the process in all the running tasks matching <task>")
+	cmd.Flags().IntVar(&parallel, "parallel", 10, "Maximum number of tasks to launch the process in at a time, with --all-matching")
+	cmd.Flags().StringVar(&record, "record", "", "Record the session to an asciicast file, which can be played with 'dcos task replay'")
 	cmd.Flags().SetInterspersed(false)
 	cmd.DisableFlagsInUseLine = true
 	return cmd
 }
+
+type execResult struct {
+	taskID   string
+	exitCode int
+	err      error
+}
+
+// execAllMatching launches a process in each running task matching id, at most parallel at a time.
+func execAllMatching(ctx api.Context, id string, command string, args []string, user string, parallel int) error {
+	tasks, err := findTasks(ctx, taskFilters{Active: true, ID: id})
+	if err != nil {
+		return err
+	}
+
+	results := runExec(tasks, parallel, func(task *mesos.Task, stdout *prefixWriter, stderr *prefixWriter) (int, error) {
+		taskIO, err := newTaskIOForTask(ctx, task, mesos.TaskIOOpts{
+			Stdout: stdout,
+			Stderr: stderr,
+			User:   user,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return taskIO.Exec(command, args...)
+	}, ctx.Out(), ctx.ErrOut())
+
+	fmt.Fprintln(ctx.Out())
+	table := cli.NewTable(ctx.Out(), []string{"TASK", "EXIT CODE", "ERROR"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	var failed int
+	for _, result := range results {
+		switch {
+		case result.err != nil:
+			failed++
+			table.Append([]string{result.taskID, notAvailable, result.err.Error()})
+		default:
+			if result.exitCode != 0 {
+				failed++
+			}
+			table.Append([]string{result.taskID, strconv.Itoa(result.exitCode), ""})
+		}
+	}
+	table.Render()
+
+	if failed > 0 {
+		return fmt.Errorf("the command failed in %d of %d task(s)", failed, len(results))
+	}
+	return nil
+}
+
+// runExec calls exec for each task, at most parallel at a time, with writers prefixing
+// the output of each task with its ID. The results are returned in the order of tasks.
+func runExec(tasks []mesos.Task, parallel int, exec func(*mesos.Task, *prefixWriter, *prefixWriter) (int, error),
+	stdout io.Writer, stderr io.Writer) []execResult {
+	var outMu, errMu sync.Mutex
+	results := make([]execResult, len(tasks))
+	sem := make(chan struct{}, parallel)
+
+	var wg sync.WaitGroup
+	for i := range tasks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			task := &tasks[i]
+			taskStdout := &prefixWriter{mu: &outMu, out: stdout, prefix: task.ID + " | "}
+			taskStderr := &prefixWriter{mu: &errMu, out: stderr, prefix: task.ID + " | "}
+
+			exitCode, err := exec(task, taskStdout, taskStderr)
+			taskStdout.Flush()
+			taskStderr.Flush()
+			results[i] = execResult{taskID: task.ID, exitCode: exitCode, err: err}
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,68 @@
+package task
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dcos/dcos-core-cli/pkg/mesos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := &prefixWriter{mu: &sync.Mutex{}, out: &out, prefix: "web.1 | "}
+
+	fmt.Fprint(w, "hello")
+	assert.Equal(t, "", out.String())
+	fmt.Fprint(w, " world\nsecond line\nthird")
+	assert.Equal(t, "web.1 | hello world\nweb.1 | second line\n", out.String())
+
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, "web.1 | hello world\nweb.1 | second line\nweb.1 | third\n", out.String())
+}
+
+func TestRunExec(t *testing.T) {
+	tasks := []mesos.Task{{ID: "web.1"}, {ID: "web.2"}, {ID: "web.3"}, {ID: "web.4"}}
+
+	var running, maxRunning int32
+	var stdout, stderr bytes.Buffer
+	results := runExec(tasks, 2, func(task *mesos.Task, out *prefixWriter, errOut *prefixWriter) (int, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+
+		switch task.ID {
+		case "web.3":
+			fmt.Fprint(errOut, "no such file\n")
+			return 2, nil
+		case "web.4":
+			return 0, errors.New("agent unreachable")
+		}
+		fmt.Fprintf(out, "uptime of %s", task.ID)
+		return 0, nil
+	}, &stdout, &stderr)
+
+	assert.True(t, maxRunning <= 2)
+	assert.Equal(t, []execResult{
+		{taskID: "web.1"},
+		{taskID: "web.2"},
+		{taskID: "web.3", exitCode: 2},
+		{taskID: "web.4", err: errors.New("agent unreachable")},
+	}, results)
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	sort.Strings(lines)
+	assert.Equal(t, []string{"web.1 | uptime of web.1", "web.2 | uptime of web.2"}, lines)
+	assert.Equal(t, "web.3 | no such file\n", stderr.String())
+}
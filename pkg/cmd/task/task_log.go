@@ -1,7 +1,10 @@
 package task
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"time"
 
 	"github.com/dcos/dcos-cli/api"
 	"github.com/dcos/dcos-core-cli/pkg/logs"
@@ -11,9 +14,10 @@ import (
 
 func newCmdTaskLog(ctx api.Context) *cobra.Command {
 	// all and completed are useless but we keep them for retrocompatibility.
-	var all, completed, follow bool
+	var all, completed, follow, noReconnect bool
 	var lines int
 	var file, output string
+	var since, until, grep string
 
 	cmd := &cobra.Command{
 		Use:   "log <task> [<file>]",
@@ -34,6 +38,18 @@ func newCmdTaskLog(ctx api.Context) *cobra.Command {
 			}
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := taskLogOptions(since, until, grep)
+			if err != nil {
+				return err
+			}
+			opts.Skip = -1 * lines
+			opts.NoReconnect = noReconnect
+			// Without an explicit number of lines, a time range is looked up in the whole log.
+			if !opts.Since.IsZero() && !cmd.Flags().Changed("lines") {
+				opts.Cursor = "BEG"
+				opts.Skip = 0
+			}
+
 			filters := taskFilters{
 				Active:    !completed,
 				Completed: all || completed,
@@ -47,22 +63,14 @@ func newCmdTaskLog(ctx api.Context) *cobra.Command {
 
 			// Only one task matching or multiple tasks but no follow.
 			if len(tasks) == 1 || follow == false {
-				if output != "short" {
-					output = "short"
-					ctx.Logger().Info(`Task logs don't support output options. Defaulting to "short"...`)
-				}
-
 				var failed bool
 				for _, task := range tasks {
 					if len(tasks) > 1 {
 						fmt.Fprintln(ctx.Out(), fmt.Sprintf("===> %s <===", task.ID))
 					}
 					logClient := logs.NewClient(pluginutil.HTTPClient(""), ctx.Out())
-					opts := logs.Options{
-						Follow: follow,
-						Format: output,
-						Skip:   -1 * lines,
-					}
+					opts.Follow = follow
+					opts.Format = output
 					err := logClient.PrintTask(task.ID, file, opts)
 					if err != nil {
 						failed = true
@@ -78,29 +86,26 @@ func newCmdTaskLog(ctx api.Context) *cobra.Command {
 
 			// Follow multiple tasks.
 			if output != "cat" {
-				ctx.Logger().Info(`Task logs don't support output options. Defaulting to "cat"...`)
+				ctx.Logger().Info(`Following multiple tasks doesn't support output options. Defaulting to "cat"...`)
 			}
+			opts.Follow = true
+			opts.Format = "cat"
 
 			// The channel receiving the content of the logs. Each task followed dumps its logs in it.
 			msgChan := make(chan taskData)
 			errChan := make(chan error)
 			for _, task := range tasks {
-				go func(taskID string, lines int, file string, c chan taskData, e chan error) {
+				go func(taskID string, file string, c chan taskData, e chan error) {
 					taskOut := &taskWriter{
 						task:   taskID,
 						writer: c,
 					}
 					logClient := logs.NewClient(pluginutil.HTTPClient(""), taskOut)
-					opts := logs.Options{
-						Follow: true,
-						Format: "cat",
-						Skip:   -1 * lines,
-					}
 					err := logClient.FollowTask(taskID, file, false, opts)
 					if err != nil {
 						e <- err
 					}
-				}(task.ID, lines, file, msgChan, errChan)
+				}(task.ID, file, msgChan, errChan)
 			}
 
 			lastTask := ""
@@ -122,11 +127,42 @@ func newCmdTaskLog(ctx api.Context) *cobra.Command {
 	cmd.Flags().BoolVar(&all, "all", false, "Print completed and in-progress tasks")
 	cmd.Flags().BoolVar(&completed, "completed", false, "Print completed tasks")
 	cmd.Flags().BoolVar(&follow, "follow", false, "Dynamically update the log")
+	cmd.Flags().BoolVar(&noReconnect, "no-reconnect", false, "Stop following the log when its stream is interrupted instead of resuming it")
 	cmd.Flags().IntVar(&lines, "lines", 10, "Print the N last lines. 10 is the default")
-	cmd.Flags().StringVarP(&output, "output", "o", "short", "Format log message output")
+	cmd.Flags().StringVarP(&output, "output", "o", "cat", "Format log message output")
+	cmd.Flags().StringVar(&since, "since", "", "Only print entries newer than a duration such as 10m, or a date such as '2006-01-02 15:04:05'")
+	cmd.Flags().StringVar(&until, "until", "", "Only print entries older than a duration such as 10m, or a date such as '2006-01-02 15:04:05'")
+	cmd.Flags().StringVar(&grep, "grep", "", "Only print entries whose message matches a regular expression, highlighting the matches")
 	return cmd
 }
 
+// taskLogOptions returns the log options filtering entries by time range and regular expression.
+func taskLogOptions(since string, until string, grep string) (logs.Options, error) {
+	var opts logs.Options
+	var err error
+
+	now := time.Now()
+	if since != "" {
+		if opts.Since, err = logs.ParseTime(since, now); err != nil {
+			return opts, err
+		}
+	}
+	if until != "" {
+		if opts.Until, err = logs.ParseTime(until, now); err != nil {
+			return opts, err
+		}
+	}
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Until.Before(opts.Since) {
+		return opts, errors.New("the time given to 'until' is before the time given to 'since'")
+	}
+	if grep != "" {
+		if opts.Grep, err = regexp.Compile(grep); err != nil {
+			return opts, fmt.Errorf("invalid regular expression for 'grep': %s", err)
+		}
+	}
+	return opts, nil
+}
+
 type taskData struct {
 	task string
 	data []byte
@@ -0,0 +1,29 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLogOptions(t *testing.T) {
+	opts, err := taskLogOptions("2h", "1h", "error|warn")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-2*time.Hour), opts.Since, time.Minute)
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), opts.Until, time.Minute)
+	assert.True(t, opts.Grep.MatchString("a warning"))
+
+	opts, err = taskLogOptions("", "", "")
+	require.NoError(t, err)
+	assert.True(t, opts.Since.IsZero())
+	assert.True(t, opts.Until.IsZero())
+	assert.Nil(t, opts.Grep)
+
+	_, err = taskLogOptions("1h", "2h", "")
+	assert.EqualError(t, err, "the time given to 'until' is before the time given to 'since'")
+
+	_, err = taskLogOptions("", "", "(")
+	assert.Error(t, err)
+}
@@ -0,0 +1,133 @@
+package task
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/mesos"
+	"github.com/spf13/cobra"
+)
+
+const portForwardDescription = `Forward a local port to a port of a running task
+
+Connections to the local port are relayed to 127.0.0.1:<remote> in the network
+namespace of the task, through a process launched next to the task for each of
+them. The container of the task must provide 'socat', 'nc' or 'bash'.`
+
+// relayScript relays its stdin and stdout to the TCP port $1 on the loopback interface.
+const relayScript = `if command -v socat >/dev/null 2>&1; then exec socat - "TCP:127.0.0.1:$1"; fi
+if command -v nc >/dev/null 2>&1; then exec nc 127.0.0.1 "$1"; fi
+if command -v bash >/dev/null 2>&1; then
+	exec bash -c 'exec 3<>"/dev/tcp/127.0.0.1/$0" || exit 1; cat <&3 & cat >&3; wait' "$1"
+fi
+echo "none of socat, nc or bash is available in the task" >&2
+exit 127`
+
+// newCmdTaskPortForward forwards a local port to a port of a task.
+func newCmdTaskPortForward(ctx api.Context) *cobra.Command {
+	var address string
+	var user string
+
+	cmd := &cobra.Command{
+		Use:   "port-forward <task> <local>:<remote>",
+		Short: "Forward a local port to a port of a running task",
+		Long:  portForwardDescription,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			localPort, remotePort, err := parsePortMapping(args[1])
+			if err != nil {
+				return err
+			}
+
+			task, err := findTask(ctx, taskFilters{Active: true, ID: args[0]})
+			if err != nil {
+				return err
+			}
+
+			ln, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(localPort)))
+			if err != nil {
+				return err
+			}
+			defer ln.Close()
+
+			fmt.Fprintf(ctx.Out(), "Forwarding from %s to port %d of task %s\n", ln.Addr(), remotePort, task.ID)
+			return servePortForward(ctx, ln, func(conn net.Conn) error {
+				return forwardConnection(ctx, task, conn, remotePort, user)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "127.0.0.1", "Local address to listen on")
+	cmd.Flags().StringVarP(&user, "user", "u", "", "Run the relay process in the task as the given user")
+	return cmd
+}
+
+// parsePortMapping parses a port mapping of the form <local>:<remote>.
+// A local port of 0 lets the system choose an available port.
+func parsePortMapping(mapping string) (int, int, error) {
+	parts := strings.Split(mapping, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid port mapping '%s', expected <local>:<remote>", mapping)
+	}
+
+	local, err := strconv.Atoi(parts[0])
+	if err != nil || local < 0 || local > 65535 {
+		return 0, 0, fmt.Errorf("invalid local port '%s'", parts[0])
+	}
+	remote, err := strconv.Atoi(parts[1])
+	if err != nil || remote < 1 || remote > 65535 {
+		return 0, 0, fmt.Errorf("invalid remote port '%s'", parts[1])
+	}
+	return local, remote, nil
+}
+
+// servePortForward accepts connections on ln and forwards each of them concurrently.
+// It returns when the listener fails, errors of single connections are only reported.
+func servePortForward(ctx api.Context, ln net.Listener, forward func(net.Conn) error) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(ctx.Out(), "Handling connection from %s\n", conn.RemoteAddr())
+		go func() {
+			defer conn.Close()
+			if err := forward(conn); err != nil {
+				fmt.Fprintf(ctx.ErrOut(), "Error: connection from %s: %s\n", conn.RemoteAddr(), err)
+			}
+		}()
+	}
+}
+
+// forwardConnection relays conn to the remote port of the task through a nested container session.
+func forwardConnection(ctx api.Context, task *mesos.Task, conn net.Conn, remotePort int, user string) error {
+	var stderr bytes.Buffer
+	taskIO, err := newTaskIOForTask(ctx, task, mesos.TaskIOOpts{
+		Stdin:       conn,
+		Stdout:      conn,
+		Stderr:      &stderr,
+		Interactive: true,
+		User:        user,
+	})
+	if err != nil {
+		return err
+	}
+
+	exitCode, err := taskIO.Exec("sh", "-c", relayScript, "sh", strconv.Itoa(remotePort))
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if exitCode != 0 {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("relay exited with code %d: %s", exitCode, msg)
+		}
+		return fmt.Errorf("relay exited with code %d", exitCode)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package task
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePortMapping(t *testing.T) {
+	local, remote, err := parsePortMapping("8080:9090")
+	require.NoError(t, err)
+	assert.Equal(t, 8080, local)
+	assert.Equal(t, 9090, remote)
+
+	local, remote, err = parsePortMapping("0:5432")
+	require.NoError(t, err)
+	assert.Equal(t, 0, local)
+	assert.Equal(t, 5432, remote)
+
+	_, _, err = parsePortMapping("8080")
+	assert.EqualError(t, err, "invalid port mapping '8080', expected <local>:<remote>")
+	_, _, err = parsePortMapping("http:9090")
+	assert.EqualError(t, err, "invalid local port 'http'")
+	_, _, err = parsePortMapping("8080:0")
+	assert.EqualError(t, err, "invalid remote port '0'")
+}
+
+func TestServePortForwardConcurrentConnections(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	env := mock.NewEnvironment()
+	env.Out = &bytes.Buffer{}
+	env.ErrOut = &bytes.Buffer{}
+	ctx := mock.NewContext(env)
+
+	// Each connection stays open until all of them have been accepted,
+	// which would deadlock if connections were forwarded sequentially.
+	const connections = 3
+	var accepted sync.WaitGroup
+	accepted.Add(connections)
+	go servePortForward(ctx, ln, func(conn net.Conn) error {
+		accepted.Done()
+		accepted.Wait()
+		_, err := io.Copy(conn, conn)
+		return err
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < connections; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			conn, err := net.Dial("tcp", ln.Addr().String())
+			require.NoError(t, err)
+			defer conn.Close()
+
+			fmt.Fprintf(conn, "ping %d\n", i)
+			line, err := bufio.NewReader(conn).ReadString('\n')
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("ping %d\n", i), line)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,103 @@
+package task
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/mesos"
+	"github.com/spf13/cobra"
+)
+
+// newCmdTaskReplay plays back a session recorded by `task attach` or `task exec`.
+func newCmdTaskReplay(ctx api.Context) *cobra.Command {
+	var speed float64
+	var idleLimit time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "replay <file>",
+		Short: "Play back a session recorded with --record by 'dcos task attach' or 'dcos task exec'",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if speed <= 0 {
+				return errors.New("the speed must be greater than 0")
+			}
+
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			return replay(f, ctx.Out(), speed, idleLimit, time.Sleep)
+		},
+	}
+
+	cmd.Flags().Float64Var(&speed, "speed", 1, "Playback speed, e.g. 2 plays the session twice as fast")
+	cmd.Flags().DurationVar(&idleLimit, "idle-limit", 0, "Maximum time to wait between two outputs, e.g. 2s (0 means no limit)")
+	return cmd
+}
+
+// replay writes the output events of an asciicast v2 recording to out,
+// calling sleep to reproduce the delays between them.
+func replay(r io.Reader, out io.Writer, speed float64, idleLimit time.Duration, sleep func(time.Duration)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		return errors.New("the recording is empty")
+	}
+	var header mesos.CastHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return fmt.Errorf("invalid recording header: %s", err)
+	}
+	if header.Version != 2 {
+		return fmt.Errorf("unsupported asciicast version %d, only version 2 is supported", header.Version)
+	}
+
+	var last float64
+	for line := 2; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var event []interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("invalid event on line %d: %s", line, err)
+		}
+		if len(event) != 3 {
+			return fmt.Errorf("invalid event on line %d: expected 3 elements, got %d", line, len(event))
+		}
+		timestamp, ok1 := event[0].(float64)
+		eventType, ok2 := event[1].(string)
+		data, ok3 := event[2].(string)
+		if !ok1 || !ok2 || !ok3 {
+			return fmt.Errorf("invalid event on line %d", line)
+		}
+		if eventType != mesos.CastEventOutput {
+			continue
+		}
+
+		delay := time.Duration((timestamp - last) / speed * float64(time.Second))
+		if idleLimit > 0 && delay > idleLimit {
+			delay = idleLimit
+		}
+		if delay > 0 {
+			sleep(delay)
+		}
+		last = timestamp
+
+		if _, err := io.WriteString(out, data); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,36 @@
+package task
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const recording = `{"version": 2, "width": 80, "height": 24, "timestamp": 1600000000}
+[0.5, "o", "$ "]
+[1.0, "i", "ls\r"]
+[1.5, "o", "ls\r\n"]
+[11.5, "o", "app.conf\r\n"]
+[12.0, "r", "100x30"]
+`
+
+func TestReplay(t *testing.T) {
+	var out bytes.Buffer
+	var sleeps []time.Duration
+	sleep := func(d time.Duration) { sleeps = append(sleeps, d) }
+
+	err := replay(strings.NewReader(recording), &out, 2, 3*time.Second, sleep)
+	require.NoError(t, err)
+
+	assert.Equal(t, "$ ls\r\napp.conf\r\n", out.String())
+	assert.Equal(t, []time.Duration{250 * time.Millisecond, 500 * time.Millisecond, 3 * time.Second}, sleeps)
+}
+
+func TestReplayUnsupportedVersion(t *testing.T) {
+	err := replay(strings.NewReader(`{"version": 1, "width": 80, "height": 24, "stdout": []}`), &bytes.Buffer{}, 1, 0, func(time.Duration) {})
+	assert.EqualError(t, err, "unsupported asciicast version 1, only version 2 is supported")
+}
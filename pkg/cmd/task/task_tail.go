@@ -0,0 +1,143 @@
+package task
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-core-cli/pkg/mesos"
+	"github.com/dcos/dcos-core-cli/pkg/pluginutil"
+	"github.com/spf13/cobra"
+)
+
+// tailChunkSize is the number of bytes read at once when looking for the last lines of a file.
+const tailChunkSize = 64 * 1024
+
+// newCmdTaskTail prints the last lines of a file from the sandbox of tasks.
+func newCmdTaskTail(ctx api.Context) *cobra.Command {
+	var all, completed, follow bool
+	var lines int
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "tail <task> <path>",
+		Short: "Print the last lines of a file from the sandbox of tasks",
+		Long: `Print the last lines of a file from the sandbox of tasks
+
+The <path> argument is relative to the sandbox of the task, it can be any file
+such as an application log under 'logs/'. With --follow, the file is polled for
+new content every --interval and is read again from its start when it shrinks.
+When several tasks match <task>, each line is prefixed with the ID of its task.`,
+		Args: cobra.ExactArgs(2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return errors.New("the flag 'interval' must be greater than 0")
+			}
+			// We support negative lines for consistency with 'dcos task log'.
+			if lines < 0 {
+				lines *= -1
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tasks, err := findTasks(ctx, taskFilters{
+				Active:    !completed,
+				Completed: all || completed,
+				ID:        args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			c := mesos.NewClient(pluginutil.HTTPClient(""))
+			return readTaskFiles(ctx, c, tasks, args[1], func(f *sandboxFile, w io.Writer) error {
+				offset, err := f.tail(lines, w)
+				if err != nil || !follow {
+					return err
+				}
+				return f.follow(offset, w, interval, nil)
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "Include completed and in-progress tasks")
+	cmd.Flags().BoolVar(&completed, "completed", false, "Only include completed tasks")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Output appended data as the file grows")
+	cmd.Flags().IntVarP(&lines, "lines", "n", 10, "Print the N last lines. 10 is the default")
+	cmd.Flags().DurationVar(&interval, "interval", time.Second, "Interval between two polls of the file, with --follow")
+	return cmd
+}
+
+// tail writes the last lines of the file to w and returns the offset of its end.
+func (f *sandboxFile) tail(lines int, w io.Writer) (int64, error) {
+	size, err := f.size()
+	if err != nil {
+		return 0, err
+	}
+	offset, err := f.tailOffset(size, lines)
+	if err != nil {
+		return 0, err
+	}
+	return f.copyFrom(offset, w)
+}
+
+// tailOffset returns the offset of the first of the last lines of a file of the given size.
+func (f *sandboxFile) tailOffset(size int64, lines int) (int64, error) {
+	if lines == 0 {
+		return size, nil
+	}
+
+	var newlines int
+	for end := size; end > 0; {
+		start := end - tailChunkSize
+		if start < 0 {
+			start = 0
+		}
+		chunk, err := f.read(start, end-start)
+		if err != nil {
+			return 0, err
+		}
+
+		for i := len(chunk) - 1; i >= 0; i-- {
+			// The newline ending the file terminates the last line rather than starting a new one.
+			if chunk[i] != '\n' || start+int64(i) == size-1 {
+				continue
+			}
+			newlines++
+			if newlines == lines {
+				return start + int64(i) + 1, nil
+			}
+		}
+		end = start
+	}
+	return 0, nil
+}
+
+// follow polls the file every interval and writes its content from offset as it grows, until done is closed.
+// The file is read again from its start when it becomes smaller than offset, e.g. when it has been rotated.
+func (f *sandboxFile) follow(offset int64, w io.Writer, interval time.Duration, done <-chan struct{}) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return nil
+		case <-ticker.C:
+		}
+
+		size, err := f.size()
+		if err != nil {
+			return err
+		}
+		if size == offset {
+			continue
+		}
+		if size < offset {
+			offset = 0
+		}
+		if offset, err = f.copyFrom(offset, w); err != nil {
+			return err
+		}
+	}
+}
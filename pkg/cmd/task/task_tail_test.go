@@ -0,0 +1,210 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/dcos/dcos-core-cli/pkg/mesos"
+	"github.com/dcos/dcos-core-cli/pkg/pluginutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// filesServer serves the files API of an agent, returning at most 5 bytes per read to exercise paging.
+type filesServer struct {
+	mu    sync.Mutex
+	files map[string]string
+}
+
+func (s *filesServer) set(path string, content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[path] = content
+}
+
+func (s *filesServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/agent/a1/files/debug":
+		json.NewEncoder(w).Encode(map[string]string{
+			"/frameworks/f1/executors/web.1/runs/c1": "/sandbox/web.1",
+			"/frameworks/f1/executors/web.2/runs/c2": "/sandbox/web.2",
+		})
+	case "/agent/a1/files/read":
+		content, ok := s.files[r.URL.Query().Get("path")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		length, _ := strconv.Atoi(r.URL.Query().Get("length"))
+		if offset == -1 {
+			json.NewEncoder(w).Encode(mesos.FileData{Offset: int64(len(content))})
+			return
+		}
+		if length > 5 {
+			length = 5
+		}
+		end := offset + length
+		if end > len(content) {
+			end = len(content)
+		}
+		if offset > end {
+			offset = end
+		}
+		json.NewEncoder(w).Encode(mesos.FileData{Data: content[offset:end], Offset: int64(offset)})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestSandboxFile(t *testing.T, content string) (*sandboxFile, *filesServer, func()) {
+	server := &filesServer{files: map[string]string{"/sandbox/web.1/logs/app.log": content}}
+	ts := httptest.NewServer(server)
+	f := &sandboxFile{
+		c:     mesos.NewClient(pluginutil.HTTPClient(ts.URL)),
+		agent: "a1",
+		path:  "/sandbox/web.1/logs/app.log",
+	}
+	return f, server, ts.Close
+}
+
+func TestSandboxFileCopy(t *testing.T) {
+	f, _, closeServer := newTestSandboxFile(t, "first line\nsecond line\n")
+	defer closeServer()
+
+	var out bytes.Buffer
+	offset, err := f.copyFrom(0, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "first line\nsecond line\n", out.String())
+	assert.Equal(t, int64(23), offset)
+}
+
+func TestSandboxFileTail(t *testing.T) {
+	content := "1\n22\n333\n4444\n"
+	f, _, closeServer := newTestSandboxFile(t, content)
+	defer closeServer()
+
+	expected := map[int]string{
+		0:  "",
+		1:  "4444\n",
+		2:  "333\n4444\n",
+		4:  content,
+		10: content,
+	}
+	for lines, tail := range expected {
+		var out bytes.Buffer
+		offset, err := f.tail(lines, &out)
+		require.NoError(t, err)
+		assert.Equal(t, tail, out.String(), "%d lines", lines)
+		assert.Equal(t, int64(len(content)), offset)
+	}
+
+	f, _, closeServer = newTestSandboxFile(t, "no\ntrailing newline")
+	defer closeServer()
+	var out bytes.Buffer
+	_, err := f.tail(1, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "trailing newline", out.String())
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestSandboxFileFollow(t *testing.T) {
+	f, server, closeServer := newTestSandboxFile(t, "first\n")
+	defer closeServer()
+
+	var out syncBuffer
+	done := make(chan struct{})
+	followed := make(chan error)
+	go func() {
+		followed <- f.follow(6, &out, time.Millisecond, done)
+	}()
+
+	waitFor := func(expected string) {
+		require.Eventually(t, func() bool { return out.String() == expected }, time.Second, time.Millisecond)
+	}
+
+	server.set("/sandbox/web.1/logs/app.log", "first\nsecond\n")
+	waitFor("second\n")
+
+	// The file has been truncated, it is read again from the start.
+	server.set("/sandbox/web.1/logs/app.log", "third\n")
+	waitFor("second\nthird\n")
+
+	close(done)
+	assert.NoError(t, <-followed)
+}
+
+func TestReadTaskFiles(t *testing.T) {
+	server := &filesServer{files: map[string]string{
+		"/sandbox/web.1/logs/app.log": "one\ntwo\n",
+		"/sandbox/web.2/logs/app.log": "three\n",
+	}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	tasks := []mesos.Task{newSandboxTask("web.1", "c1"), newSandboxTask("web.2", "c2")}
+	c := mesos.NewClient(pluginutil.HTTPClient(ts.URL))
+
+	var out bytes.Buffer
+	env := mock.NewEnvironment()
+	env.Out = &out
+	err := readTaskFiles(mock.NewContext(env), c, tasks, "logs/app.log", func(f *sandboxFile, w io.Writer) error {
+		_, err := f.copyFrom(0, w)
+		return err
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	sort.Strings(lines)
+	assert.Equal(t, []string{"web.1 | one", "web.1 | two", "web.2 | three"}, lines)
+
+	server.set("/sandbox/web.1/logs/app.log", "single\n")
+	out.Reset()
+	err = readTaskFiles(mock.NewContext(env), c, tasks[:1], "logs/app.log", func(f *sandboxFile, w io.Writer) error {
+		_, err := f.copyFrom(0, w)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "single\n", out.String())
+}
+
+func newSandboxTask(id string, containerID string) mesos.Task {
+	return mesos.Task{
+		ID:          id,
+		FrameworkID: "f1",
+		SlaveID:     "a1",
+		Statuses: []mesos.TaskStatus{{
+			ContainerStatus: mesos.ContainerStatus{ContainerID: mesos.ContainerID{Value: containerID}},
+		}},
+	}
+}
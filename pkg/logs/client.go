@@ -2,21 +2,37 @@ package logs
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
+	"regexp"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/dcos/dcos-cli/pkg/httpclient"
 	"github.com/r3labs/sse"
 	"golang.org/x/crypto/ssh/terminal"
+	backoff "gopkg.in/cenkalti/backoff.v1"
 )
 
+// maxEntrySize is the maximum size of a JSON log entry.
+const maxEntrySize = 16 * 1024 * 1024
+
+// reconnectInitialInterval and reconnectMaxInterval bound the backoff between two attempts to resume a followed log.
+var (
+	reconnectInitialInterval = 500 * time.Millisecond
+	reconnectMaxInterval     = 30 * time.Second
+)
+
+// streamIdleTimeout is the time after which a followed log sending no data is considered dropped and is resumed.
+var streamIdleTimeout = 5 * time.Minute
+
 // Client is a logs client for DC/OS.
 type Client struct {
 	http    *httpclient.Client
@@ -30,6 +46,34 @@ type Options struct {
 	Follow  bool
 	Format  string
 	Skip    int
+
+	// Cursor is the position to read task logs from, "END" when empty.
+	Cursor string
+
+	// Since and Until filter the entries by time, zero values are unbounded.
+	// Entries without a timestamp are never filtered out.
+	Since time.Time
+	Until time.Time
+
+	// Grep only keeps the entries whose message matches it, the matches are highlighted.
+	Grep *regexp.Regexp
+
+	// NoReconnect ends a followed log when its stream is interrupted rather than resuming it.
+	NoReconnect bool
+}
+
+// matches reports whether an entry passes the time and grep filters of the options.
+func (o Options) matches(entry *Entry) bool {
+	if entry.RealtimeTimestamp != 0 {
+		timestamp := time.Unix(0, entry.RealtimeTimestamp*int64(time.Microsecond))
+		if !o.Since.IsZero() && timestamp.Before(o.Since) {
+			return false
+		}
+		if !o.Until.IsZero() && timestamp.After(o.Until) {
+			return false
+		}
+	}
+	return o.Grep == nil || o.Grep.MatchString(entry.Fields.Message)
 }
 
 // NewClient creates a new logs client.
@@ -47,36 +91,15 @@ func NewClient(baseClient *httpclient.Client, out io.Writer) *Client {
 
 // PrintComponent prints a component's logs.
 func (c *Client) PrintComponent(route string, service string, opts Options) error {
-	requestFilters := ""
-	if len(opts.Filters) > 0 {
-		requestFilters = "&filter=" + strings.Join(opts.Filters, "&filter=")
-	}
-	endpoint := fmt.Sprintf("/system/v1%s/logs/v2/component%s?skip=%d%s", route, service, opts.Skip, requestFilters)
 	if opts.Follow {
-		client := sse.NewClient(c.http.BaseURL().String() + endpoint)
-		client.Connection = c.http.BaseClient()
-		client.Headers["Authorization"] = c.http.Header().Get("Authorization")
-		client.Headers["User-Agent"] = c.http.Header().Get("User-Agent")
-
-		events := make(chan *sse.Event)
-		err := client.SubscribeChanRaw(events)
-		if err != nil {
-			return err
-		}
-		defer client.Unsubscribe(events)
-
-		for msg := range events {
-			if len(msg.Data) == 0 {
-				continue
-			}
-			err := c.printEntry(msg.Data, opts)
-			if err != nil {
-				return err
-			}
-		}
-		return nil
+		return c.follow(opts, func(opts Options) string {
+			return componentEndpoint(route, service, opts)
+		}, func(entry *Entry) error {
+			return c.writeEntry(entry, opts)
+		})
 	}
 
+	endpoint := componentEndpoint(route, service, opts)
 	resp, err := c.http.Get(endpoint, httpclient.Header("Accept", "application/json"))
 	if err != nil {
 		return err
@@ -86,23 +109,19 @@ func (c *Client) PrintComponent(route string, service string, opts Options) erro
 	if resp.StatusCode != 200 {
 		return httpResponseToError(resp)
 	}
-	for scanner := bufio.NewScanner(resp.Body); scanner.Scan(); {
-		err := c.printEntry(scanner.Bytes(), opts)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	return c.printEntries(resp.Body, opts)
 }
 
-// PrintTask prints a task's logs.
+// PrintTask prints a task's logs, the messages are printed as is when no format is given.
 func (c *Client) PrintTask(taskID string, file string, opts Options) error {
+	if opts.Format == "" {
+		opts.Format = "cat"
+	}
 	if opts.Follow {
 		return c.FollowTask(taskID, file, true, opts)
 	}
 
-	endpoint := fmt.Sprintf("/system/v1/logs/v2/task/%s/file/%s?cursor=END&skip=%d", taskID, file, opts.Skip)
-	resp, err := c.http.Get(endpoint, httpclient.Header("Accept", "text/plain"))
+	resp, err := c.http.Get(taskEndpoint(taskID, file, opts), httpclient.Header("Accept", "application/json"))
 	if err != nil {
 		return err
 	}
@@ -111,57 +130,206 @@ func (c *Client) PrintTask(taskID string, file string, opts Options) error {
 	if resp.StatusCode != 200 {
 		return httpResponseToError(resp)
 	}
-
-	// Due to a bug in `dcos-log` we can't receive task logs as json
-	// Output option for task will be ignored for now
-	// TODO(rgoegge): Refactor to receive application/json once `dcos-log is fixed
-	b, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	fmt.Fprint(c.out, string(b))
-	return nil
+	return c.printEntries(resp.Body, opts)
 }
 
 // FollowTask follows a task's logs.
 func (c *Client) FollowTask(taskID string, file string, printLogs bool, opts Options) error {
-	endpoint := fmt.Sprintf("/system/v1/logs/v2/task/%s/file/%s?cursor=END&skip=%d", taskID, file, opts.Skip)
+	return c.follow(opts, func(opts Options) string {
+		return taskEndpoint(taskID, file, opts)
+	}, func(entry *Entry) error {
+		if printLogs {
+			return c.writeEntry(entry, opts)
+		}
+		return c.dumpEntry(entry, opts)
+	})
+}
 
-	client := sse.NewClient(c.http.BaseURL().String() + endpoint)
-	client.Connection = c.http.BaseClient()
-	client.Headers["Authorization"] = c.http.Header().Get("Authorization")
-	client.Headers["User-Agent"] = c.http.Header().Get("User-Agent")
+// follow streams the entries of a log and calls handle for each of them, endpoint returning the
+// path of the stream for the given options. When the stream is interrupted, it is resumed with
+// exponential backoff from the cursor of the last entry received, unless opts.NoReconnect is set.
+func (c *Client) follow(opts Options, endpoint func(Options) string, handle func(*Entry) error) error {
+	var cursor string
+	var connected bool
+	backOff := newReconnectBackOff()
 
-	events := make(chan *sse.Event)
-	err := client.SubscribeChanRaw(events)
-	if err != nil {
-		return err
+	for {
+		streamOpts := opts
+		if cursor != "" {
+			streamOpts.Cursor = cursor
+			streamOpts.Skip = 0
+		}
+
+		resp, err := c.http.Get(endpoint(streamOpts), httpclient.Header("Accept", "text/event-stream"), httpclient.Timeout(0))
+		if err == nil && resp.StatusCode != 200 {
+			err = httpResponseToError(resp)
+			resp.Body.Close()
+			// Client errors such as an unknown task or an expired token won't be solved by reconnecting.
+			if resp.StatusCode < 500 {
+				return err
+			}
+		}
+		if err != nil && !connected {
+			return err
+		}
+
+		if err == nil {
+			connected = true
+			var received bool
+			var handleErr error
+			body := newIdleTimeoutReader(resp.Body, streamIdleTimeout)
+			received, handleErr, err = readEntries(body, &cursor, handle)
+			body.Close()
+			if body.timedOut() {
+				err = fmt.Errorf("no data received from the log stream for %s", streamIdleTimeout)
+			}
+			if handleErr != nil {
+				return handleErr
+			}
+			if received {
+				backOff.Reset()
+			}
+		}
+
+		if opts.NoReconnect {
+			return err
+		}
+		time.Sleep(backOff.NextBackOff())
 	}
-	defer client.Unsubscribe(events)
+}
+
+// readEntries calls handle for each entry of an event stream until it ends and keeps track of the cursor of the
+// last entry, an entry at this cursor being skipped as it has already been handled before a reconnection.
+// It returns whether entries have been received, the error returned by handle, and the error of the stream.
+func readEntries(r io.Reader, cursor *string, handle func(*Entry) error) (bool, error, error) {
+	var received bool
+	reader := sse.NewEventStreamReader(r)
+	for {
+		event, err := reader.ReadEvent()
+		if err == io.EOF {
+			return received, nil, nil
+		}
+		if err != nil {
+			return received, nil, err
+		}
 
-	for msg := range events {
-		if len(msg.Data) == 0 {
+		data := eventData(event)
+		if len(data) == 0 {
 			continue
 		}
 
-		if printLogs {
-			err = c.printEntry(msg.Data, opts)
-		} else {
-			err = c.dumpEntry(msg.Data)
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return received, err, nil
+		}
+		if entry.Cursor != "" && entry.Cursor == *cursor {
+			continue
 		}
+		received = true
+		if entry.Cursor != "" {
+			*cursor = entry.Cursor
+		}
+		if err := handle(&entry); err != nil {
+			return received, err, nil
+		}
+	}
+}
 
+// idleTimeoutReader closes a stream when no data is read from it for a given time,
+// interrupting a blocked read on a connection which has been silently dropped.
+type idleTimeoutReader struct {
+	body    io.ReadCloser
+	timeout time.Duration
+	timer   *time.Timer
+	expired int32
+}
+
+func newIdleTimeoutReader(body io.ReadCloser, timeout time.Duration) *idleTimeoutReader {
+	r := &idleTimeoutReader{body: body, timeout: timeout}
+	r.timer = time.AfterFunc(timeout, func() {
+		atomic.StoreInt32(&r.expired, 1)
+		r.body.Close()
+	})
+	return r
+}
+
+func (r *idleTimeoutReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	if n > 0 {
+		r.timer.Reset(r.timeout)
+	}
+	return n, err
+}
+
+func (r *idleTimeoutReader) Close() error {
+	r.timer.Stop()
+	return r.body.Close()
+}
+
+// timedOut reports whether the stream has been closed because it was idle.
+func (r *idleTimeoutReader) timedOut() bool {
+	return atomic.LoadInt32(&r.expired) == 1
+}
+
+// eventData returns the data of a server-sent event, multiple data fields being joined with newlines.
+func eventData(event []byte) []byte {
+	var data [][]byte
+	for _, line := range bytes.FieldsFunc(event, func(r rune) bool { return r == '\n' || r == '\r' }) {
+		if bytes.HasPrefix(line, []byte("data:")) {
+			data = append(data, bytes.TrimPrefix(line[len("data:"):], []byte(" ")))
+		}
+	}
+	return bytes.Join(data, []byte("\n"))
+}
+
+// newReconnectBackOff returns the backoff between two attempts to resume an interrupted log stream.
+func newReconnectBackOff() *backoff.ExponentialBackOff {
+	backOff := backoff.NewExponentialBackOff()
+	backOff.InitialInterval = reconnectInitialInterval
+	backOff.MaxInterval = reconnectMaxInterval
+	backOff.MaxElapsedTime = 0
+	backOff.Reset()
+	return backOff
+}
+
+func componentEndpoint(route string, service string, opts Options) string {
+	var params string
+	if opts.Cursor != "" {
+		params = "&cursor=" + url.QueryEscape(opts.Cursor)
+	}
+	if len(opts.Filters) > 0 {
+		params += "&filter=" + strings.Join(opts.Filters, "&filter=")
+	}
+	return fmt.Sprintf("/system/v1%s/logs/v2/component%s?skip=%d%s", route, service, opts.Skip, params)
+}
+
+func taskEndpoint(taskID string, file string, opts Options) string {
+	cursor := opts.Cursor
+	if cursor == "" {
+		cursor = "END"
+	}
+	return fmt.Sprintf("/system/v1/logs/v2/task/%s/file/%s?cursor=%s&skip=%d", taskID, file, url.QueryEscape(cursor), opts.Skip)
+}
+
+// printEntries prints the newline-delimited JSON entries read from r.
+func (c *Client) printEntries(r io.Reader, opts Options) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxEntrySize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		err := c.printEntry(scanner.Bytes(), opts)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	return scanner.Err()
 }
 
-func (c *Client) dumpEntry(rawEntry []byte) error {
-	var entry Entry
-	err := json.Unmarshal(rawEntry, &entry)
-	if err != nil {
-		return err
+func (c *Client) dumpEntry(entry *Entry, opts Options) error {
+	if !opts.matches(entry) {
+		return nil
 	}
 	// TODO: there should be a well-defined API for following logs, eg. using a channel instead of an io.Writer.
 	fmt.Fprint(c.out, entry.Fields.Message)
@@ -174,6 +342,13 @@ func (c *Client) printEntry(rawEntry []byte, opts Options) error {
 	if err != nil {
 		return err
 	}
+	return c.writeEntry(&entry, opts)
+}
+
+func (c *Client) writeEntry(entry *Entry, opts Options) error {
+	if !opts.matches(entry) {
+		return nil
+	}
 
 	enc := json.NewEncoder(c.out)
 	switch opts.Format {
@@ -184,7 +359,7 @@ func (c *Client) printEntry(rawEntry []byte, opts Options) error {
 		return enc.Encode(entry.JournalctlJSON())
 	case "cat":
 		c.setColor(entry.Fields.Priority)
-		fmt.Fprint(c.out, entry.Fields.Message)
+		fmt.Fprint(c.out, c.highlight(entry.Fields.Message, entry.Fields.Priority, opts))
 		c.resetColor()
 	default:
 		c.setColor(entry.Fields.Priority)
@@ -199,7 +374,7 @@ func (c *Client) printEntry(rawEntry []byte, opts Options) error {
 			entry.Fields.SyslogIdentifier,
 			pid,
 			": ",
-			entry.Fields.Message,
+			c.highlight(entry.Fields.Message, entry.Fields.Priority, opts),
 		)
 		c.resetColor()
 	}
@@ -210,22 +385,37 @@ func (c *Client) printEntry(rawEntry []byte, opts Options) error {
 
 func (c *Client) setColor(priority string) {
 	if c.colored {
-		var color string
-		switch priority {
-		// EMERGENCY, ALERT, CRITICAL, ERROR are printed in red.
-		case "0", "1", "2", "3":
-			color = "31"
-		// WARNING is printed in yellow.
-		case "4":
-			color = "33"
-		// NOTICE is printed in bright blue.
-		case "5":
-			color = "34;1"
-		default:
-			color = "0"
-		}
-		fmt.Fprintf(c.out, "\033[0;%sm", color)
+		fmt.Fprint(c.out, priorityColor(priority))
+	}
+}
+
+// priorityColor returns the escape sequence setting the color of a log priority.
+func priorityColor(priority string) string {
+	var color string
+	switch priority {
+	// EMERGENCY, ALERT, CRITICAL, ERROR are printed in red.
+	case "0", "1", "2", "3":
+		color = "31"
+	// WARNING is printed in yellow.
+	case "4":
+		color = "33"
+	// NOTICE is printed in bright blue.
+	case "5":
+		color = "34;1"
+	default:
+		color = "0"
+	}
+	return "\033[0;" + color + "m"
+}
+
+// highlight prints the matches of the grep expression in reverse video, restoring the color of the priority after each of them.
+func (c *Client) highlight(message string, priority string, opts Options) string {
+	if !c.colored || opts.Grep == nil {
+		return message
 	}
+	return opts.Grep.ReplaceAllStringFunc(message, func(match string) string {
+		return "\033[7m" + match + priorityColor(priority)
+	})
 }
 
 func (c *Client) resetColor() {
@@ -245,3 +435,26 @@ func httpResponseToError(resp *http.Response) error {
 		Response: resp,
 	}
 }
+
+// timeLayouts are the layouts accepted by ParseTime, in local time unless a zone is given.
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTime parses a point in time given either as a duration before now, e.g. "10m",
+// or as a date, e.g. "2020-09-21", "2020-09-21 15:04:05" or "2020-09-21T15:04:05Z".
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time '%s', expected a duration such as 10m or a date such as 2006-01-02 15:04:05", value)
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/dcos/dcos-core-cli/pkg/pluginutil"
 	"github.com/stretchr/testify/assert"
@@ -146,6 +148,8 @@ func TestPrintTask(t *testing.T) {
 		file           string
 		task           string
 		skip           int
+		cursor         string
+		format         string
 		filters        []string
 		entries        []*Entry
 		colored        bool
@@ -156,6 +160,7 @@ func TestPrintTask(t *testing.T) {
 			file:           "stdout",
 			task:           "f70251-task-1",
 			skip:           -10,
+			format:         "cat",
 			entries:        []*Entry{entry},
 			expectedPath:   "/system/v1/logs/v2/task/f70251-task-1/file/stdout?cursor=END&skip=-10",
 			expectedOutput: "info message\n",
@@ -164,6 +169,7 @@ func TestPrintTask(t *testing.T) {
 			file:           "stderr",
 			task:           "944c71-task-2",
 			skip:           5,
+			format:         "cat",
 			entries:        []*Entry{entry},
 			expectedPath:   "/system/v1/logs/v2/task/944c71-task-2/file/stderr?cursor=END&skip=5",
 			expectedOutput: "info message\n",
@@ -175,15 +181,41 @@ func TestPrintTask(t *testing.T) {
 			expectedPath:   "/system/v1/logs/v2/task/256164-task-3/file/stdout?cursor=END&skip=0",
 			expectedOutput: "info message\n",
 		},
+		{
+			file:           "stdout",
+			task:           "256164-task-3",
+			format:         "short",
+			entries:        []*Entry{entry},
+			expectedPath:   "/system/v1/logs/v2/task/256164-task-3/file/stdout?cursor=END&skip=0",
+			expectedOutput: "1970-01-01 00:00:00 UTC: info message\n",
+		},
+		{
+			file:           "stdout",
+			task:           "256164-task-3",
+			cursor:         "BEG",
+			format:         "cat",
+			entries:        []*Entry{entry},
+			expectedPath:   "/system/v1/logs/v2/task/256164-task-3/file/stdout?cursor=BEG&skip=0",
+			expectedOutput: "info message\n",
+		},
+		{
+			file:         "stdout",
+			task:         "256164-task-3",
+			format:       "json",
+			entries:      []*Entry{entry},
+			expectedPath: "/system/v1/logs/v2/task/256164-task-3/file/stdout?cursor=END&skip=0",
+			expectedOutput: `{"__CURSOR":"","__MONOTONIC_TIMESTAMP":0,"__REALTIME_TIMESTAMP":0,"MESSAGE":"info message",` +
+				`"PRIORITY":"","SYSLOG_FACILITY":"","SYSLOG_IDENTIFIER":"","_BOOT_ID":"","_HOSTNAME":"","_MACHINE_ID":"","_TRANSPORT":""}` + "\n",
+		},
 	}
 
 	for _, fixture := range fixtures {
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "text/plain", r.Header.Get("Accept"))
+			assert.Equal(t, "application/json", r.Header.Get("Accept"))
 			assert.Equal(t, fixture.expectedPath, r.URL.String())
 
 			for _, entry := range fixture.entries {
-				fmt.Fprintln(w, entry.Fields.Message)
+				assert.NoError(t, json.NewEncoder(w).Encode(entry))
 			}
 		}))
 
@@ -193,7 +225,9 @@ func TestPrintTask(t *testing.T) {
 
 		opts := Options{
 			Filters: fixture.filters,
+			Format:  fixture.format,
 			Skip:    fixture.skip,
+			Cursor:  fixture.cursor,
 		}
 
 		err := c.PrintTask(fixture.task, fixture.file, opts)
@@ -204,3 +238,183 @@ func TestPrintTask(t *testing.T) {
 		ts.Close()
 	}
 }
+
+func TestPrintTaskFilters(t *testing.T) {
+	entries := []*Entry{
+		{RealtimeTimestamp: 1550515200000000, Fields: EntryFields{Message: "starting server"}},
+		{RealtimeTimestamp: 1550515260000000, Fields: EntryFields{Message: "GET /health 200"}},
+		{RealtimeTimestamp: 1550515320000000, Fields: EntryFields{Message: "GET /users 500", Priority: "3"}},
+		{Fields: EntryFields{Message: "GET /untimed 200"}},
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, entry := range entries {
+			assert.NoError(t, json.NewEncoder(w).Encode(entry))
+		}
+	}))
+	defer ts.Close()
+
+	fixtures := []struct {
+		opts           Options
+		colored        bool
+		expectedOutput string
+	}{
+		{
+			opts:           Options{Format: "cat", Since: time.Unix(1550515260, 0)},
+			expectedOutput: "GET /health 200\nGET /users 500\nGET /untimed 200\n",
+		},
+		{
+			opts:           Options{Format: "cat", Until: time.Unix(1550515260, 0)},
+			expectedOutput: "starting server\nGET /health 200\nGET /untimed 200\n",
+		},
+		{
+			opts:           Options{Format: "cat", Grep: regexp.MustCompile(`GET /\w+ 200`)},
+			expectedOutput: "GET /health 200\nGET /untimed 200\n",
+		},
+		{
+			opts:           Options{Format: "cat", Grep: regexp.MustCompile(`50\d`)},
+			colored:        true,
+			expectedOutput: "\x1b[0;31mGET /users \x1b[7m500\x1b[0;31m\x1b[0m\n",
+		},
+	}
+
+	for _, fixture := range fixtures {
+		var b bytes.Buffer
+		c := NewClient(pluginutil.HTTPClient(ts.URL), &b)
+		c.colored = fixture.colored
+
+		err := c.PrintTask("task-1", "stdout", fixture.opts)
+		require.NoError(t, err)
+		assert.Equal(t, fixture.expectedOutput, b.String())
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2020, 9, 21, 12, 0, 0, 0, time.UTC)
+
+	parsed, err := ParseTime("90m", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 9, 21, 10, 30, 0, 0, time.UTC), parsed)
+
+	parsed, err = ParseTime("2020-09-20T08:00:00Z", now)
+	require.NoError(t, err)
+	assert.True(t, parsed.Equal(time.Date(2020, 9, 20, 8, 0, 0, 0, time.UTC)))
+
+	parsed, err = ParseTime("2020-09-20 08:00:00", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 9, 20, 8, 0, 0, 0, time.Local), parsed)
+
+	parsed, err = ParseTime("2020-09-20", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 9, 20, 0, 0, 0, 0, time.Local), parsed)
+
+	_, err = ParseTime("yesterday", now)
+	assert.EqualError(t, err, "invalid time 'yesterday', expected a duration such as 10m or a date such as 2006-01-02 15:04:05")
+}
+
+func TestFollowTaskReconnect(t *testing.T) {
+	reconnectInitialInterval = time.Millisecond
+	defer func() { reconnectInitialInterval = 500 * time.Millisecond }()
+
+	streams := [][]string{
+		{"c1", "c2"},
+		{"c2", "c3"},
+	}
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		paths = append(paths, r.URL.String())
+		if len(paths) > len(streams) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		for _, cursor := range streams[len(paths)-1] {
+			entry, err := json.Marshal(&Entry{Cursor: cursor, Fields: EntryFields{Message: "message " + cursor}})
+			require.NoError(t, err)
+			fmt.Fprintf(w, "data: %s\n\n", entry)
+		}
+	}))
+	defer ts.Close()
+
+	var b bytes.Buffer
+	c := NewClient(pluginutil.HTTPClient(ts.URL), &b)
+
+	err := c.FollowTask("task-1", "stdout", true, Options{Format: "cat", Skip: -10})
+	assert.Error(t, err)
+	assert.Equal(t, "message c1\nmessage c2\nmessage c3\n", b.String())
+	assert.Equal(t, []string{
+		"/system/v1/logs/v2/task/task-1/file/stdout?cursor=END&skip=-10",
+		"/system/v1/logs/v2/task/task-1/file/stdout?cursor=c2&skip=0",
+		"/system/v1/logs/v2/task/task-1/file/stdout?cursor=c3&skip=0",
+	}, paths)
+}
+
+func TestFollowTaskIdleTimeout(t *testing.T) {
+	reconnectInitialInterval = time.Millisecond
+	streamIdleTimeout = 50 * time.Millisecond
+	defer func() {
+		reconnectInitialInterval = 500 * time.Millisecond
+		streamIdleTimeout = 5 * time.Minute
+	}()
+
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.String())
+		if len(paths) > 1 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "data: {\"cursor\":\"c1\",\"fields\":{\"MESSAGE\":\"message c1\"}}\n\n")
+		w.(http.Flusher).Flush()
+		// The connection is kept open without sending anything, as if it had been silently dropped.
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	var b bytes.Buffer
+	c := NewClient(pluginutil.HTTPClient(ts.URL), &b)
+
+	err := c.FollowTask("task-1", "stdout", true, Options{Format: "cat", Skip: -10})
+	assert.Error(t, err)
+	assert.Equal(t, "message c1\n", b.String())
+	assert.Equal(t, []string{
+		"/system/v1/logs/v2/task/task-1/file/stdout?cursor=END&skip=-10",
+		"/system/v1/logs/v2/task/task-1/file/stdout?cursor=c1&skip=0",
+	}, paths)
+}
+
+func TestFollowComponentNoReconnect(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/system/v1/leader/mesos/logs/v2/component?skip=-1&filter=_PID:1", r.URL.String())
+		fmt.Fprint(w, "data: {\"cursor\":\"c1\",\"fields\":{\"MESSAGE\":\"message\"}}\n\n")
+	}))
+	defer ts.Close()
+
+	var b bytes.Buffer
+	c := NewClient(pluginutil.HTTPClient(ts.URL), &b)
+
+	opts := Options{Follow: true, Format: "cat", Skip: -1, Filters: []string{"_PID:1"}, NoReconnect: true}
+	err := c.PrintComponent("/leader/mesos", "", opts)
+	require.NoError(t, err)
+	assert.Equal(t, "message\n", b.String())
+	assert.Equal(t, 1, requests)
+}
+
+func TestFollowInitialError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := NewClient(pluginutil.HTTPClient(ts.URL), &bytes.Buffer{})
+	err := c.FollowTask("task-1", "stdout", true, Options{})
+	assert.Error(t, err)
+}
+
+func TestComponentEndpoint(t *testing.T) {
+	opts := Options{Skip: 0, Cursor: "s=abc;i=1", Filters: []string{"_PID:1"}}
+	assert.Equal(t, "/system/v1/agent/a1/logs/v2/component/dcos-mesos-slave.service?skip=0&cursor=s%3Dabc%3Bi%3D1&filter=_PID:1",
+		componentEndpoint("/agent/a1", "/dcos-mesos-slave.service", opts))
+}
@@ -0,0 +1,291 @@
+package marathon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ResourceKind is the kind of a Marathon resource managed by apply.
+type ResourceKind string
+
+const (
+	KindApp   ResourceKind = "app"
+	KindPod   ResourceKind = "pod"
+	KindGroup ResourceKind = "group"
+)
+
+// Resource is an app, a pod or a group definition. The definition of a group only holds
+// its own fields, such as its dependencies, its apps, pods and subgroups being resources of their own.
+type Resource struct {
+	Kind       ResourceKind
+	ID         string
+	Definition map[string]interface{}
+	// Source is the file the resource has been loaded from, it is empty for live resources.
+	Source string
+}
+
+// PlanAction is the action to take on a resource to reach its desired state.
+type PlanAction string
+
+const (
+	ActionCreate PlanAction = "create"
+	ActionUpdate PlanAction = "update"
+	ActionDelete PlanAction = "delete"
+)
+
+// PlanStep is an action to take on a resource, along with the changes it brings for updates.
+type PlanStep struct {
+	Action   PlanAction
+	Resource Resource
+	Changes  []Change
+}
+
+// LoadResources loads the app, pod and group definitions from the JSON file at location or, if it is
+// a directory, from all the JSON files found in it recursively. Groups are split into a group resource
+// and the resources of their apps, pods and subgroups, whose relative IDs are resolved against its ID.
+func LoadResources(location string) ([]Resource, error) {
+	var files []string
+	err := filepath.Walk(location, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && (file == location || strings.EqualFold(filepath.Ext(file), ".json")) {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []Resource
+	seen := make(map[string]string)
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var definition map[string]interface{}
+		if err := json.Unmarshal(data, &definition); err != nil {
+			return nil, fmt.Errorf("error loading JSON from %s: %s", file, err)
+		}
+
+		fileResources, err := definitionResources(definition, "/")
+		if err != nil {
+			return nil, fmt.Errorf("invalid definition in %s: %s", file, err)
+		}
+		for _, resource := range fileResources {
+			if source, ok := seen[resource.ID]; ok {
+				return nil, fmt.Errorf("'%s' is defined both in %s and %s", resource.ID, source, file)
+			}
+			seen[resource.ID] = file
+			resource.Source = file
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+// definitionResources returns the resources of an app, pod or group definition whose parent group is parentID.
+func definitionResources(definition map[string]interface{}, parentID string) ([]Resource, error) {
+	id, ok := definition["id"].(string)
+	if !ok || id == "" {
+		return nil, fmt.Errorf("missing ID")
+	}
+	id = resolveID(parentID, id)
+
+	if !isGroupDefinition(definition) {
+		resource := Resource{Kind: KindApp, ID: id, Definition: StripServerFields(definition)}
+		if _, ok := definition["containers"]; ok {
+			resource.Kind = KindPod
+		}
+		resource.Definition["id"] = id
+		return []Resource{resource}, nil
+	}
+
+	group := Resource{Kind: KindGroup, ID: id, Definition: groupDefinition(definition)}
+	group.Definition["id"] = id
+	resources := []Resource{group}
+	for _, field := range []string{"apps", "pods", "groups"} {
+		children, _ := definition[field].([]interface{})
+		for _, child := range children {
+			childDefinition, ok := child.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("group '%s' has an invalid '%s' entry", id, field)
+			}
+			childResources, err := definitionResources(childDefinition, id)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, childResources...)
+		}
+	}
+	return resources, nil
+}
+
+func isGroupDefinition(definition map[string]interface{}) bool {
+	for _, field := range []string{"apps", "pods", "groups"} {
+		if _, ok := definition[field]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// groupDefinition returns the fields of a group definition which aren't managed by Marathon,
+// without its apps, pods and subgroups.
+func groupDefinition(group map[string]interface{}) map[string]interface{} {
+	definition := StripServerFields(group)
+	for _, field := range []string{"apps", "pods", "groups"} {
+		delete(definition, field)
+	}
+	return definition
+}
+
+func resolveID(parentID string, id string) string {
+	if strings.HasPrefix(id, "/") {
+		return path.Clean(id)
+	}
+	return path.Join(parentID, id)
+}
+
+// LiveResources returns the groups, apps and pods currently deployed under the group groupID, by ID.
+func (c *Client) LiveResources(groupID string) (map[string]Resource, error) {
+	group, err := c.RawGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make(map[string]Resource)
+	collectLiveResources(group, resources)
+	return resources, nil
+}
+
+func collectLiveResources(group map[string]interface{}, resources map[string]Resource) {
+	if id, ok := group["id"].(string); ok {
+		resources[id] = Resource{Kind: KindGroup, ID: id, Definition: groupDefinition(group)}
+	}
+	for field, kind := range map[string]ResourceKind{"apps": KindApp, "pods": KindPod} {
+		definitions, _ := group[field].([]interface{})
+		for _, definition := range definitions {
+			if definition, ok := definition.(map[string]interface{}); ok {
+				id, _ := definition["id"].(string)
+				resources[id] = Resource{Kind: kind, ID: id, Definition: StripServerFields(definition)}
+			}
+		}
+	}
+
+	groups, _ := group["groups"].([]interface{})
+	for _, subgroup := range groups {
+		if subgroup, ok := subgroup.(map[string]interface{}); ok {
+			collectLiveResources(subgroup, resources)
+		}
+	}
+}
+
+// ComputePlan returns the steps needed to go from the live resources to the desired ones. Live resources
+// are only compared on the top-level fields set in their desired definition, as Marathon fills in defaults
+// for the others. When pruneGroup is set, live apps and pods under this group which aren't desired are deleted.
+// Groups are never deleted, as their apps and pods may be desired without a definition of the group.
+// Steps are ordered by action (creations, updates then deletions) and by resource ID, so that groups
+// are created before their apps and pods.
+func ComputePlan(desired []Resource, live map[string]Resource, pruneGroup string) ([]PlanStep, error) {
+	var plan []PlanStep
+	desiredIDs := make(map[string]bool)
+
+	for _, resource := range desired {
+		desiredIDs[resource.ID] = true
+
+		liveResource, ok := live[resource.ID]
+		if !ok {
+			plan = append(plan, PlanStep{Action: ActionCreate, Resource: resource})
+			continue
+		}
+		if liveResource.Kind != resource.Kind {
+			return nil, fmt.Errorf("cannot replace %s '%s' with a %s definition", liveResource.Kind, resource.ID, resource.Kind)
+		}
+
+		changes := Diff(projectOnto(liveResource.Definition, resource.Definition), resource.Definition)
+		if len(changes) > 0 {
+			plan = append(plan, PlanStep{Action: ActionUpdate, Resource: resource, Changes: changes})
+		}
+	}
+
+	if pruneGroup != "" {
+		prefix := strings.TrimSuffix(NormalizeAppID(pruneGroup), "/") + "/"
+		for id, resource := range live {
+			if !desiredIDs[id] && resource.Kind != KindGroup && strings.HasPrefix(id, prefix) {
+				plan = append(plan, PlanStep{Action: ActionDelete, Resource: resource})
+			}
+		}
+	}
+
+	actionOrder := map[PlanAction]int{ActionCreate: 0, ActionUpdate: 1, ActionDelete: 2}
+	sort.SliceStable(plan, func(i, j int) bool {
+		if plan[i].Action != plan[j].Action {
+			return actionOrder[plan[i].Action] < actionOrder[plan[j].Action]
+		}
+		return plan[i].Resource.ID < plan[j].Resource.ID
+	})
+	return plan, nil
+}
+
+// projectOnto returns the top-level fields of live which are also set in desired. The values of these
+// fields are kept whole, a key removed from a map such as env or labels is thus seen as a change.
+func projectOnto(live interface{}, desired interface{}) interface{} {
+	desiredValue, ok := desired.(map[string]interface{})
+	if !ok {
+		return live
+	}
+	liveValue, ok := live.(map[string]interface{})
+	if !ok {
+		return live
+	}
+	result := make(map[string]interface{}, len(desiredValue))
+	for key := range desiredValue {
+		if liveField, ok := liveValue[key]; ok {
+			result[key] = liveField
+		}
+	}
+	return result
+}
+
+// ApplyStep executes a plan step and returns the ID of the created deployment.
+func (c *Client) ApplyStep(step PlanStep, force bool) (string, error) {
+	resource := step.Resource
+	switch {
+	case step.Action == ActionCreate && resource.Kind == KindApp:
+		return c.CreateApp(resource.Definition)
+	case step.Action == ActionCreate && resource.Kind == KindPod:
+		return c.CreatePod(resource.Definition)
+	case step.Action == ActionCreate && resource.Kind == KindGroup:
+		return c.CreateGroup(resource.Definition)
+	case step.Action == ActionUpdate && resource.Kind == KindApp:
+		return c.UpdateApp(resource.ID, resource.Definition, force)
+	case step.Action == ActionUpdate && resource.Kind == KindPod:
+		return c.ReplacePod(resource.ID, resource.Definition, force)
+	case step.Action == ActionUpdate && resource.Kind == KindGroup:
+		return c.UpdateGroup(resource.ID, resource.Definition, force)
+	case step.Action == ActionDelete && resource.Kind == KindApp:
+		deploymentID, err := c.API.DeleteApplication(resource.ID, force)
+		if err != nil {
+			return "", err
+		}
+		return deploymentID.DeploymentID, nil
+	case step.Action == ActionDelete && resource.Kind == KindPod:
+		deploymentID, err := c.API.DeletePod(resource.ID, force)
+		if err != nil {
+			return "", err
+		}
+		return deploymentID.DeploymentID, nil
+	default:
+		return "", fmt.Errorf("unsupported action %s on %s '%s'", step.Action, resource.Kind, resource.ID)
+	}
+}
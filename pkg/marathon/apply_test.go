@@ -0,0 +1,153 @@
+package marathon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeDefinitions(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "marathon-apply")
+	require.NoError(t, err)
+
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0700))
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+	}
+	return dir
+}
+
+func TestLoadResources(t *testing.T) {
+	dir := writeDefinitions(t, map[string]string{
+		"web.json": `{"id":"/prod/web","cmd":"sleep 100","version":"2020-01-01T00:00:00.000Z"}`,
+		"prod/backend.json": `{"id":"/prod/backend","dependencies":["/prod/web"],"apps":[{"id":"api","cmd":"sleep 100"}],
+			"pods":[{"id":"/prod/backend/db","containers":[]}],"groups":[{"id":"workers","apps":[{"id":"worker"}]}]}`,
+		"README.md": "not a definition",
+	})
+	defer os.RemoveAll(dir)
+
+	resources, err := LoadResources(dir)
+	require.NoError(t, err)
+
+	ids := make(map[string]ResourceKind)
+	for _, resource := range resources {
+		ids[resource.ID] = resource.Kind
+	}
+	assert.Equal(t, map[string]ResourceKind{
+		"/prod/web":                    KindApp,
+		"/prod/backend":                KindGroup,
+		"/prod/backend/api":            KindApp,
+		"/prod/backend/db":             KindPod,
+		"/prod/backend/workers":        KindGroup,
+		"/prod/backend/workers/worker": KindApp,
+	}, ids)
+
+	for _, resource := range resources {
+		assert.Equal(t, resource.ID, resource.Definition["id"])
+		assert.NotContains(t, resource.Definition, "version")
+		assert.NotContains(t, resource.Definition, "apps")
+		if resource.ID == "/prod/backend" {
+			assert.Equal(t, []interface{}{"/prod/web"}, resource.Definition["dependencies"])
+		}
+	}
+}
+
+func TestLoadResourcesDuplicate(t *testing.T) {
+	dir := writeDefinitions(t, map[string]string{
+		"a.json": `{"id":"/web","cmd":"sleep 100"}`,
+		"b.json": `{"id":"/","apps":[{"id":"web"}]}`,
+	})
+	defer os.RemoveAll(dir)
+
+	_, err := LoadResources(dir)
+	assert.EqualError(t, err, "'/web' is defined both in "+filepath.Join(dir, "a.json")+" and "+filepath.Join(dir, "b.json"))
+}
+
+func TestComputePlan(t *testing.T) {
+	desired := []Resource{
+		{Kind: KindApp, ID: "/prod/web", Definition: map[string]interface{}{"id": "/prod/web", "instances": float64(3)}},
+		{Kind: KindApp, ID: "/prod/api", Definition: map[string]interface{}{"id": "/prod/api", "instances": float64(1)}},
+		{Kind: KindPod, ID: "/prod/db", Definition: map[string]interface{}{"id": "/prod/db"}},
+	}
+	live := map[string]Resource{
+		"/prod/web":   {Kind: KindApp, ID: "/prod/web", Definition: map[string]interface{}{"id": "/prod/web", "instances": float64(1), "backoffFactor": 1.15}},
+		"/prod/api":   {Kind: KindApp, ID: "/prod/api", Definition: map[string]interface{}{"id": "/prod/api", "instances": float64(1), "backoffFactor": 1.15}},
+		"/prod/old":   {Kind: KindApp, ID: "/prod/old"},
+		"/production": {Kind: KindApp, ID: "/production"},
+		"/staging/x":  {Kind: KindApp, ID: "/staging/x"},
+	}
+
+	plan, err := ComputePlan(desired, live, "")
+	require.NoError(t, err)
+	require.Len(t, plan, 2)
+	assert.Equal(t, ActionCreate, plan[0].Action)
+	assert.Equal(t, "/prod/db", plan[0].Resource.ID)
+	assert.Equal(t, ActionUpdate, plan[1].Action)
+	assert.Equal(t, "/prod/web", plan[1].Resource.ID)
+	assert.Equal(t, []Change{{Type: ChangeUpdated, Path: "/instances", Old: float64(1), New: float64(3)}}, plan[1].Changes)
+
+	plan, err = ComputePlan(desired, live, "/prod")
+	require.NoError(t, err)
+	require.Len(t, plan, 3)
+	assert.Equal(t, ActionDelete, plan[2].Action)
+	assert.Equal(t, "/prod/old", plan[2].Resource.ID)
+}
+
+func TestComputePlanRemovedKeys(t *testing.T) {
+	desired := []Resource{{Kind: KindApp, ID: "/web", Definition: map[string]interface{}{
+		"id":     "/web",
+		"env":    map[string]interface{}{"PORT": "80"},
+		"labels": map[string]interface{}{},
+	}}}
+	live := map[string]Resource{"/web": {Kind: KindApp, ID: "/web", Definition: map[string]interface{}{
+		"id":            "/web",
+		"env":           map[string]interface{}{"PORT": "80", "DEBUG": "true"},
+		"labels":        map[string]interface{}{"HAPROXY_GROUP": "external"},
+		"backoffFactor": 1.15,
+	}}}
+
+	plan, err := ComputePlan(desired, live, "")
+	require.NoError(t, err)
+	require.Len(t, plan, 1)
+	assert.Equal(t, ActionUpdate, plan[0].Action)
+	assert.Equal(t, []Change{
+		{Type: ChangeRemoved, Path: "/env/DEBUG", Old: "true"},
+		{Type: ChangeRemoved, Path: "/labels/HAPROXY_GROUP", Old: "external"},
+	}, plan[0].Changes)
+}
+
+func TestComputePlanGroups(t *testing.T) {
+	desired := []Resource{
+		{Kind: KindGroup, ID: "/prod", Definition: map[string]interface{}{"id": "/prod", "dependencies": []interface{}{"/db"}}},
+		{Kind: KindGroup, ID: "/prod/backend", Definition: map[string]interface{}{"id": "/prod/backend", "enforceRole": true}},
+		{Kind: KindApp, ID: "/prod/backend/api", Definition: map[string]interface{}{"id": "/prod/backend/api"}},
+	}
+	live := map[string]Resource{
+		"/prod":     {Kind: KindGroup, ID: "/prod", Definition: map[string]interface{}{"id": "/prod", "enforceRole": false}},
+		"/prod/old": {Kind: KindGroup, ID: "/prod/old", Definition: map[string]interface{}{"id": "/prod/old"}},
+	}
+
+	plan, err := ComputePlan(desired, live, "/prod")
+	require.NoError(t, err)
+	require.Len(t, plan, 3)
+	assert.Equal(t, ActionCreate, plan[0].Action)
+	assert.Equal(t, "/prod/backend", plan[0].Resource.ID)
+	assert.Equal(t, ActionCreate, plan[1].Action)
+	assert.Equal(t, "/prod/backend/api", plan[1].Resource.ID)
+	assert.Equal(t, ActionUpdate, plan[2].Action)
+	assert.Equal(t, KindGroup, plan[2].Resource.Kind)
+	assert.Equal(t, []Change{{Type: ChangeAdded, Path: "/dependencies", New: []interface{}{"/db"}}}, plan[2].Changes)
+}
+
+func TestComputePlanKindMismatch(t *testing.T) {
+	desired := []Resource{{Kind: KindPod, ID: "/web", Definition: map[string]interface{}{"id": "/web"}}}
+	live := map[string]Resource{"/web": {Kind: KindApp, ID: "/web"}}
+
+	_, err := ComputePlan(desired, live, "")
+	assert.EqualError(t, err, "cannot replace app '/web' with a pod definition")
+}
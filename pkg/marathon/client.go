@@ -1,6 +1,7 @@
 package marathon
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,11 +11,13 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/dcos/dcos-cli/api"
 	"github.com/dcos/dcos-cli/pkg/httpclient"
 	"github.com/dcos/dcos-core-cli/pkg/pluginutil"
 	goMarathon "github.com/gambol99/go-marathon"
+	"github.com/r3labs/sse"
 	"github.com/sirupsen/logrus"
 )
 
@@ -26,6 +29,8 @@ const InsufficientDisk = "InsufficientDisk"
 const InsufficientPorts = "InsufficientPorts"
 const DeclinedScarceResources = "DeclinedScarceResources"
 
+const deploymentIDHeader = "Marathon-Deployment-Id"
+
 var httpRegexp = regexp.MustCompile("^(http|https)$")
 
 // Client to interact with the Marathon API.
@@ -44,6 +49,10 @@ func (e ErrAppAlreadyExists) Error() string {
 
 var ErrCannotReadAppDefinition = errors.New("cannot read app definition")
 
+var ErrCannotReadPodDefinition = errors.New("cannot read pod definition")
+
+var ErrCannotReadGroupDefinition = errors.New("cannot read group definition")
+
 // NewClient creates a new HTTP wrapper client to talk to the Marathon service.
 func NewClient(ctx api.Context) (*Client, error) {
 	cluster, err := ctx.Cluster()
@@ -72,12 +81,20 @@ func NewClient(ctx api.Context) (*Client, error) {
 
 // AddApp creates a deployment from the app definition referenced in appFile which can be a local
 // file name or an HTTP URL. If appFile is empty, the definition is read from ctx.Input().
-func (c *Client) AddApp(ctx api.Context, appLocation string) (*goMarathon.Application, error) {
+// When vars is not nil, the definition is rendered as a template with them first.
+func (c *Client) AddApp(ctx api.Context, appLocation string, vars map[string]interface{}) (*goMarathon.Application, error) {
 	appBytes, err := getAppDefinition(ctx, appLocation)
 	if err != nil {
 		return nil, ErrCannotReadAppDefinition
 	}
 
+	if vars != nil {
+		appBytes, err = RenderDefinition(ctx, appBytes, vars)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var app map[string]interface{}
 	err = json.Unmarshal(appBytes, &app)
 	if err != nil {
@@ -301,6 +318,54 @@ func (c *Client) Deployments() ([]goMarathon.Deployment, error) {
 	}
 }
 
+// RawDeployments returns the deployments in progress as returned by Marathon, without any
+// field being lost through decoding.
+func (c *Client) RawDeployments() ([]json.RawMessage, error) {
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Get("/v2/deployments")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		var result []json.RawMessage
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result, err
+	default:
+		return nil, errors.New("unable to get Marathon deployments")
+	}
+}
+
+// CancelDeployment cancels the deployment deploymentID. With rollback, Marathon creates a deployment
+// reverting the changes already made, whose ID is returned. Otherwise the deployment is stopped as is
+// and the returned ID is empty.
+func (c *Client) CancelDeployment(deploymentID string, rollback bool) (string, error) {
+	url := fmt.Sprintf("/v2/deployments/%s", deploymentID)
+	if !rollback {
+		url += "?force=true"
+	}
+
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Delete(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		return decodeDeploymentID(resp)
+	case 202:
+		return "", nil
+	case 404:
+		return "", fmt.Errorf("deployment '%s' does not exist", deploymentID)
+	default:
+		return "", httpResponseToError(resp)
+	}
+}
+
 func (c *Client) Queue() (goMarathon.Queue, error) {
 	dcosClient := pluginutil.HTTPClient(c.baseURL)
 	resp, err := dcosClient.Get("/v2/queue")
@@ -393,3 +458,715 @@ func (c *Client) ApplicationByVersion(appID string, version string) (*goMarathon
 func NormalizeAppID(appID string) string {
 	return fmt.Sprintf("/%s", strings.Trim(appID, "/"))
 }
+
+// Pods returns the status of all the Marathon pods.
+func (c *Client) Pods() ([]goMarathon.PodStatus, error) {
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Get("/v2/pods/::status")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		var result []goMarathon.PodStatus
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result, err
+	default:
+		return nil, errors.New("unable to get Marathon pods")
+	}
+}
+
+// RawPods returns the status of all the Marathon pods as returned by Marathon.
+func (c *Client) RawPods() ([]map[string]interface{}, error) {
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Get("/v2/pods/::status")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		var result []map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result, err
+	default:
+		return nil, errors.New("unable to get Marathon pods")
+	}
+}
+
+// RawPod returns the definition of the Marathon pod podID as returned by Marathon.
+func (c *Client) RawPod(podID string) (map[string]interface{}, error) {
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Get(fmt.Sprintf("/v2/pods%s", NormalizeAppID(podID)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		var result map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result, err
+	case 404:
+		return nil, fmt.Errorf("pod '%s' does not exist", NormalizeAppID(podID))
+	default:
+		return nil, httpResponseToError(resp)
+	}
+}
+
+// AddPod creates a deployment from the pod definition referenced in podLocation which can be a local
+// file name or an HTTP URL. If podLocation is empty, the definition is read from ctx.Input().
+// It returns the ID of the created deployment.
+func (c *Client) AddPod(ctx api.Context, podLocation string) (string, error) {
+	pod, err := getPodDefinition(ctx, podLocation)
+	if err != nil {
+		return "", err
+	}
+	return c.CreatePod(pod)
+}
+
+// CreatePod creates a deployment for the given pod definition and returns its ID.
+func (c *Client) CreatePod(pod map[string]interface{}) (string, error) {
+	id, ok := pod["id"].(string)
+	if !ok {
+		return "", fmt.Errorf("pod ID must be set")
+	}
+
+	body, err := json.Marshal(pod)
+	if err != nil {
+		return "", err
+	}
+
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Post("/v2/pods", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 201:
+		return resp.Header.Get(deploymentIDHeader), nil
+	case 409:
+		return "", fmt.Errorf("pod '%s' already exists", NormalizeAppID(id))
+	default:
+		return "", httpResponseToError(resp)
+	}
+}
+
+// UpdatePod replaces the definition of the pod podID with the one referenced in podLocation which
+// can be a local file name or an HTTP URL. If podLocation is empty, the definition is read from
+// ctx.Input(). It returns the ID of the created deployment.
+func (c *Client) UpdatePod(ctx api.Context, podID string, podLocation string, force bool) (string, error) {
+	pod, err := getPodDefinition(ctx, podLocation)
+	if err != nil {
+		return "", err
+	}
+	return c.ReplacePod(podID, pod, force)
+}
+
+// ReplacePod replaces the definition of the pod podID with the given one and returns
+// the ID of the created deployment.
+func (c *Client) ReplacePod(podID string, pod map[string]interface{}, force bool) (string, error) {
+	body, err := json.Marshal(pod)
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("/v2/pods%s", NormalizeAppID(podID))
+	if force {
+		url += "?force=true"
+	}
+
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Put(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200, 201:
+		return resp.Header.Get(deploymentIDHeader), nil
+	case 404:
+		return "", fmt.Errorf("pod '%s' does not exist", NormalizeAppID(podID))
+	case 409:
+		return "", errors.New("changes blocked: deployment already in progress for pod")
+	default:
+		return "", httpResponseToError(resp)
+	}
+}
+
+// getPodDefinition loads and decodes the pod definition JSON the same way getAppDefinition does.
+func getPodDefinition(ctx api.Context, location string) (map[string]interface{}, error) {
+	podBytes, err := getAppDefinition(ctx, location)
+	if err != nil {
+		return nil, ErrCannotReadPodDefinition
+	}
+
+	var pod map[string]interface{}
+	err = json.Unmarshal(podBytes, &pod)
+	if err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+// Group returns the Marathon group groupID at the given version. If version is empty,
+// the latest version of the group is returned.
+func (c *Client) Group(groupID string, version string) (*Group, error) {
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	url := fmt.Sprintf("/v2/groups%s", NormalizeAppID(groupID))
+	if version != "" {
+		url = fmt.Sprintf("/v2/groups%s/versions/%s", NormalizeAppID(groupID), version)
+	}
+
+	resp, err := dcosClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		var result Group
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return &result, err
+	case 404:
+		return nil, fmt.Errorf("group '%s' does not exist", NormalizeAppID(groupID))
+	case 422:
+		return nil, fmt.Errorf("invalid timestamp provided '%s', expecting ISO-8601 datetime string", version)
+	default:
+		return nil, httpResponseToError(resp)
+	}
+}
+
+// GroupVersions returns the versions of the Marathon group groupID, the most recent one first.
+func (c *Client) GroupVersions(groupID string) ([]string, error) {
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Get(fmt.Sprintf("/v2/groups%s/versions", NormalizeAppID(groupID)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		var result []string
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result, err
+	case 404:
+		return nil, fmt.Errorf("group '%s' does not exist", NormalizeAppID(groupID))
+	default:
+		return nil, fmt.Errorf("unable to get versions for Marathon group %s", groupID)
+	}
+}
+
+// AddGroup creates a deployment from the group definition referenced in groupLocation which can be a
+// local file name or an HTTP URL. If groupLocation is empty, the definition is read from ctx.Input().
+// It returns the ID of the created deployment.
+func (c *Client) AddGroup(ctx api.Context, groupLocation string) (string, error) {
+	groupBytes, err := getAppDefinition(ctx, groupLocation)
+	if err != nil {
+		return "", ErrCannotReadGroupDefinition
+	}
+
+	var group map[string]interface{}
+	err = json.Unmarshal(groupBytes, &group)
+	if err != nil {
+		return "", err
+	}
+	return c.CreateGroup(group)
+}
+
+// CreateGroup creates a deployment for the given group definition and returns its ID.
+func (c *Client) CreateGroup(group map[string]interface{}) (string, error) {
+	id, ok := group["id"].(string)
+	if !ok {
+		return "", fmt.Errorf("group ID must be set")
+	}
+
+	body, err := json.Marshal(group)
+	if err != nil {
+		return "", err
+	}
+
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Post("/v2/groups", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 201:
+		return decodeDeploymentID(resp)
+	case 409:
+		return "", fmt.Errorf("group '%s' already exists", NormalizeAppID(id))
+	default:
+		return "", httpResponseToError(resp)
+	}
+}
+
+// UpdateGroup applies the given changes to the Marathon group groupID and returns the ID
+// of the created deployment.
+func (c *Client) UpdateGroup(groupID string, changes map[string]interface{}, force bool) (string, error) {
+	body, err := json.Marshal(changes)
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("/v2/groups%s", NormalizeAppID(groupID))
+	if force {
+		url += "?force=true"
+	}
+
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Put(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200, 201:
+		return decodeDeploymentID(resp)
+	case 404:
+		return "", fmt.Errorf("group '%s' does not exist", NormalizeAppID(groupID))
+	case 409:
+		return "", errors.New("changes blocked: deployment already in progress for group")
+	default:
+		return "", httpResponseToError(resp)
+	}
+}
+
+// ScaleGroup scales all the apps of the Marathon group groupID by the given factor and
+// returns the ID of the created deployment.
+func (c *Client) ScaleGroup(groupID string, scaleFactor float64, force bool) (string, error) {
+	return c.UpdateGroup(groupID, map[string]interface{}{"scaleBy": scaleFactor}, force)
+}
+
+func decodeDeploymentID(resp *http.Response) (string, error) {
+	var result goMarathon.DeploymentID
+	err := json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return "", fmt.Errorf("could not unmarshal response body: %s", err)
+	}
+	return result.DeploymentID, nil
+}
+
+// ParseProperties parses a list of properties in the form key=value into a JSON object.
+// Values are decoded as JSON when possible and are otherwise kept as strings.
+func ParseProperties(properties []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	for _, property := range properties {
+		terms := strings.SplitN(property, "=", 2)
+		if len(terms) != 2 {
+			return nil, fmt.Errorf("'%s' is not a valid property, expected key=value", property)
+		}
+
+		key := unquote(terms[0])
+		if _, ok := result[key]; ok {
+			return nil, fmt.Errorf("key '%s' was specified more than once", key)
+		}
+
+		value := unquote(terms[1])
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+			parsed = value
+		}
+		result[key] = parsed
+	}
+	return result, nil
+}
+
+func unquote(s string) string {
+	if len(s) > 1 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// Events subscribes to the Marathon event stream and returns a channel receiving the events
+// of the given types, all events are received when no type is given. The channel is closed
+// when the stream ends. The returned function must be called to unsubscribe from the stream.
+func (c *Client) Events(eventTypes ...string) (<-chan *Event, func(), error) {
+	endpoint := "/v2/events"
+	if len(eventTypes) > 0 {
+		endpoint += "?" + url.Values{"event_type": eventTypes}.Encode()
+	}
+
+	dcosClient := pluginutil.HTTPClient(c.baseURL, httpclient.Timeout(0))
+	resp, err := dcosClient.Get(endpoint, httpclient.Header("Accept", "text/event-stream"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != 200 {
+		resp.Body.Close()
+		return nil, nil, httpResponseToError(resp)
+	}
+
+	events := make(chan *Event)
+	done := make(chan struct{})
+	go func() {
+		defer close(events)
+
+		reader := sse.NewEventStreamReader(resp.Body)
+		for {
+			msg, err := reader.ReadEvent()
+			if err != nil {
+				return
+			}
+			event := decodeEvent(msg)
+			if event == nil {
+				continue
+			}
+			select {
+			case events <- event:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			close(done)
+			resp.Body.Close()
+		})
+	}
+	return events, unsubscribe, nil
+}
+
+// decodeEvent decodes a raw server-sent event, it returns nil for events without data.
+func decodeEvent(msg []byte) *Event {
+	event := &Event{}
+	var data [][]byte
+	for _, line := range bytes.FieldsFunc(msg, func(r rune) bool { return r == '\n' || r == '\r' }) {
+		switch {
+		case bytes.HasPrefix(line, []byte("event:")):
+			event.Type = string(bytes.TrimSpace(line[len("event:"):]))
+		case bytes.HasPrefix(line, []byte("data:")):
+			data = append(data, bytes.TrimPrefix(line[len("data:"):], []byte(" ")))
+		}
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	event.Data = bytes.Join(data, []byte("\n"))
+
+	var eventType goMarathon.EventType
+	if err := json.Unmarshal(event.Data, &eventType); err == nil && eventType.EventType != "" {
+		event.Type = eventType.EventType
+	}
+
+	if typed, err := goMarathon.GetEvent(event.Type); err == nil {
+		if err := json.Unmarshal(event.Data, typed.Event); err == nil {
+			event.Event = typed.Event
+		}
+	}
+	return event
+}
+
+// RawApplication returns the definition of the Marathon app appID at the given version as a JSON
+// object, without any field being lost through decoding. If version is empty, the latest version
+// of the app is returned.
+func (c *Client) RawApplication(appID string, version string) (map[string]interface{}, error) {
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	url := fmt.Sprintf("/v2/apps%s", NormalizeAppID(appID))
+	if version != "" {
+		url = fmt.Sprintf("/v2/apps%s/versions/%s", NormalizeAppID(appID), version)
+	}
+
+	resp, err := dcosClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		if version == "" {
+			var result struct {
+				App map[string]interface{} `json:"app"`
+			}
+			err = json.NewDecoder(resp.Body).Decode(&result)
+			return result.App, err
+		}
+		var result map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result, err
+	case 404:
+		return nil, fmt.Errorf("app '%s' does not exist", NormalizeAppID(appID))
+	case 422:
+		return nil, fmt.Errorf("invalid timestamp provided '%s', expecting ISO-8601 datetime string", version)
+	default:
+		return nil, fmt.Errorf("unable to get version %s for app %s", version, appID)
+	}
+}
+
+// UpdateApp replaces the definition of the Marathon app appID with the given one and returns
+// the ID of the created deployment.
+func (c *Client) UpdateApp(appID string, app map[string]interface{}, force bool) (string, error) {
+	body, err := json.Marshal(app)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{"partialUpdate": []string{"false"}}
+	if force {
+		query.Set("force", "true")
+	}
+
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Put(fmt.Sprintf("/v2/apps%s?%s", NormalizeAppID(appID), query.Encode()), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200, 201:
+		return decodeDeploymentID(resp)
+	case 404:
+		return "", fmt.Errorf("app '%s' does not exist", NormalizeAppID(appID))
+	case 409:
+		return "", errors.New("changes blocked: deployment already in progress for app")
+	default:
+		return "", httpResponseToError(resp)
+	}
+}
+
+// RollbackApp redeploys the Marathon app appID at the given version and returns the ID of the created deployment.
+func (c *Client) RollbackApp(appID string, version string, force bool) (string, error) {
+	body, err := json.Marshal(map[string]string{"version": version})
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	if force {
+		query.Set("force", "true")
+	}
+
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Put(fmt.Sprintf("/v2/apps%s?%s", NormalizeAppID(appID), query.Encode()), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200, 201:
+		return decodeDeploymentID(resp)
+	case 404:
+		return "", fmt.Errorf("app '%s' does not exist", NormalizeAppID(appID))
+	case 409:
+		return "", errors.New("changes blocked: deployment already in progress for app")
+	default:
+		return "", httpResponseToError(resp)
+	}
+}
+
+// CreateApp creates a deployment for the given app definition and returns its ID.
+func (c *Client) CreateApp(app map[string]interface{}) (string, error) {
+	id, ok := app["id"].(string)
+	if !ok {
+		return "", fmt.Errorf("application ID must be set")
+	}
+
+	body, err := json.Marshal(app)
+	if err != nil {
+		return "", err
+	}
+
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Post("/v2/apps", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 201:
+		return resp.Header.Get(deploymentIDHeader), nil
+	case 409:
+		return "", ErrAppAlreadyExists{appID: id}
+	default:
+		return "", httpResponseToError(resp)
+	}
+}
+
+// RawGroup returns the Marathon group groupID with all its apps, pods and subgroups
+// as decoded JSON, including the fields which aren't part of the Group type.
+func (c *Client) RawGroup(groupID string) (map[string]interface{}, error) {
+	query := url.Values{"embed": []string{"group.groups", "group.apps", "group.pods"}}
+
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Get(fmt.Sprintf("/v2/groups%s?%s", NormalizeAppID(groupID), query.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		var result map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result, err
+	case 404:
+		return nil, fmt.Errorf("group '%s' does not exist", NormalizeAppID(groupID))
+	default:
+		return nil, httpResponseToError(resp)
+	}
+}
+
+// Tasks returns the tasks of all the Marathon apps.
+func (c *Client) Tasks() ([]Task, error) {
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Get("/v2/tasks")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		var result struct {
+			Tasks []Task `json:"tasks"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result.Tasks, err
+	default:
+		return nil, httpResponseToError(resp)
+	}
+}
+
+// KillTasksByID kills the tasks with the given IDs, wiping their persistent data if wipe is set.
+// When scale is set, the apps of the tasks are scaled down and the ID of the created deployment
+// is returned, otherwise the killed tasks are returned.
+func (c *Client) KillTasksByID(taskIDs []string, scale bool, wipe bool) ([]Task, string, error) {
+	body, err := json.Marshal(map[string][]string{"ids": taskIDs})
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := url.Values{}
+	if scale {
+		query.Set("scale", "true")
+	}
+	if wipe {
+		query.Set("wipe", "true")
+	}
+
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Post("/v2/tasks/delete?"+query.Encode(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200, 202:
+		if scale {
+			deploymentID, err := decodeDeploymentID(resp)
+			return nil, deploymentID, err
+		}
+		var result struct {
+			Tasks []Task `json:"tasks"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result.Tasks, "", err
+	default:
+		return nil, "", httpResponseToError(resp)
+	}
+}
+
+// Leader returns the address of the leading Marathon instance in the form host:port.
+func (c *Client) Leader() (string, error) {
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Get("/v2/leader")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		var result struct {
+			Leader string `json:"leader"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result.Leader, err
+	case 404:
+		return "", errors.New("there is no Marathon leader")
+	default:
+		return "", httpResponseToError(resp)
+	}
+}
+
+// DeleteLeader makes the leading Marathon instance abdicate and returns the message sent back by Marathon.
+func (c *Client) DeleteLeader() (string, error) {
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Delete("/v2/leader")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200, 202:
+		var result struct {
+			Message string `json:"message"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result.Message, err
+	case 404:
+		return "", errors.New("there is no Marathon leader")
+	default:
+		return "", httpResponseToError(resp)
+	}
+}
+
+// ResetDelay resets the launch delay of the app or pod with the given ID.
+func (c *Client) ResetDelay(id string) error {
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Delete(fmt.Sprintf("/v2/queue%s/delay", NormalizeAppID(id)))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200, 204:
+		return nil
+	case 404:
+		return fmt.Errorf("'%s' is not in the launch queue", NormalizeAppID(id))
+	default:
+		return httpResponseToError(resp)
+	}
+}
+
+// Plugins returns the plugins loaded by Marathon.
+func (c *Client) Plugins() (*Plugins, error) {
+	dcosClient := pluginutil.HTTPClient(c.baseURL)
+	resp, err := dcosClient.Get("/v2/plugins")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		var result Plugins
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return &result, err
+	default:
+		return nil, httpResponseToError(resp)
+	}
+}
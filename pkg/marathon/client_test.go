@@ -224,6 +224,36 @@ func TestDeploymentErrors(t *testing.T) {
 	}
 }
 
+func TestCancelDeployment(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		switch r.URL.String() {
+		case "/v2/deployments/d1?force=true":
+			w.WriteHeader(http.StatusAccepted)
+		case "/v2/deployments/d1":
+			w.Write([]byte(`{"deploymentId":"d2","version":"2020-01-02T00:00:00.000Z"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := Client{
+		baseURL: ts.URL,
+	}
+
+	deploymentID, err := client.CancelDeployment("d1", false)
+	require.NoError(t, err)
+	assert.Equal(t, "", deploymentID)
+
+	deploymentID, err = client.CancelDeployment("d1", true)
+	require.NoError(t, err)
+	assert.Equal(t, "d2", deploymentID)
+
+	_, err = client.CancelDeployment("unknown", true)
+	assert.EqualError(t, err, "deployment 'unknown' does not exist")
+}
+
 func TestApplicationVersions(t *testing.T) {
 	expected := goMarathon.ApplicationVersions{
 		Versions: []string{
@@ -395,7 +425,7 @@ func TestAddAppWith(t *testing.T) {
 			client := &Client{API: &marathonmocks.MarathonMock{}}
 			ctx := mock.NewContext(&cli.Environment{Input: strings.NewReader(test.input)})
 
-			newApp, err := client.AddApp(ctx, test.file)
+			newApp, err := client.AddApp(ctx, test.file, nil)
 			assert.EqualError(t, err, test.err)
 			assert.Nil(t, newApp)
 		})
@@ -428,7 +458,7 @@ func TestAddAppWithHTTPURL(t *testing.T) {
 	}
 	ctx := mock.NewContext(nil)
 
-	newApp, err := client.AddApp(ctx, ts.URL)
+	newApp, err := client.AddApp(ctx, ts.URL, nil)
 
 	assert.NoError(t, err, nil)
 	assert.NotNil(t, newApp)
@@ -497,10 +527,183 @@ func TestAddApp(t *testing.T) {
 
 			ctx := mock.NewContext(&cli.Environment{Input: strings.NewReader(`{"id":"some id"}`)})
 
-			newApp, err := client.AddApp(ctx, "")
+			newApp, err := client.AddApp(ctx, "", nil)
 
 			assert.Equal(t, tt.expectedApp, newApp)
 			assert.Equal(t, tt.expectedErr, err)
 		})
 	}
 }
+
+func TestPods(t *testing.T) {
+	expected := []goMarathon.PodStatus{
+		{
+			ID:     "/test-pod",
+			Status: goMarathon.PodStateStable,
+		},
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/pods/::status", r.URL.String())
+		assert.Equal(t, "GET", r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(expected)
+	}))
+	defer ts.Close()
+
+	client := Client{
+		baseURL: ts.URL,
+	}
+
+	pods, err := client.Pods()
+	require.NoError(t, err)
+
+	jsonEqual(t, expected, pods)
+}
+
+func TestAddPod(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		statusCode   int
+		deploymentID string
+		err          string
+	}{
+		{
+			name:         "created",
+			input:        `{"id":"/test-pod","containers":[]}`,
+			statusCode:   http.StatusCreated,
+			deploymentID: "5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43",
+		},
+		{
+			name:       "already exists",
+			input:      `{"id":"test-pod"}`,
+			statusCode: http.StatusConflict,
+			err:        "pod '/test-pod' already exists",
+		},
+		{
+			name:  "missing ID",
+			input: `{"containers":[]}`,
+			err:   "pod ID must be set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v2/pods", r.URL.String())
+				assert.Equal(t, "POST", r.Method)
+
+				var pod map[string]interface{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&pod))
+				assert.NotEmpty(t, pod["id"])
+
+				w.Header().Set("Marathon-Deployment-Id", tt.deploymentID)
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer ts.Close()
+
+			client := Client{baseURL: ts.URL}
+			ctx := mock.NewContext(&cli.Environment{Input: strings.NewReader(tt.input)})
+
+			deploymentID, err := client.AddPod(ctx, "")
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.deploymentID, deploymentID)
+		})
+	}
+}
+
+func TestUpdatePod(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/pods/test-pod?force=true", r.URL.String())
+		assert.Equal(t, "PUT", r.Method)
+
+		w.Header().Set("Marathon-Deployment-Id", "5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := Client{baseURL: ts.URL}
+	ctx := mock.NewContext(&cli.Environment{Input: strings.NewReader(`{"id":"/test-pod"}`)})
+
+	deploymentID, err := client.UpdatePod(ctx, "test-pod", "", true)
+	require.NoError(t, err)
+	assert.Equal(t, "5ed4c0c5-9ff8-4a6f-a0cd-f57f59a34b43", deploymentID)
+}
+
+func TestUpdatePodNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/pods/test-pod", r.URL.String())
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := Client{baseURL: ts.URL}
+	ctx := mock.NewContext(&cli.Environment{Input: strings.NewReader(`{"id":"/test-pod"}`)})
+
+	_, err := client.UpdatePod(ctx, "/test-pod/", "", false)
+	assert.EqualError(t, err, "pod '/test-pod' does not exist")
+}
+
+func TestParseProperties(t *testing.T) {
+	properties, err := ParseProperties([]string{
+		"instances=3",
+		"cpus=0.5",
+		"cmd=sleep 100",
+		`"labels"={"team":"infra"}`,
+		"quoted='42'",
+		"role=null",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"instances": float64(3),
+		"cpus":      0.5,
+		"cmd":       "sleep 100",
+		"labels":    map[string]interface{}{"team": "infra"},
+		"quoted":    float64(42),
+		"role":      nil,
+	}, properties)
+
+	_, err = ParseProperties([]string{"instances"})
+	assert.EqualError(t, err, "'instances' is not a valid property, expected key=value")
+
+	_, err = ParseProperties([]string{"instances=1", "instances=2"})
+	assert.EqualError(t, err, "key 'instances' was specified more than once")
+}
+
+func TestEvents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/events?event_type=status_update_event&event_type=unknown_event", r.URL.String())
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "event: status_update_event\ndata: {\"eventType\":\"status_update_event\",\"taskId\":\"app.1\",\"taskStatus\":\"TASK_RUNNING\"}\n\n")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "event: unknown_event\ndata: {\"eventType\":\"unknown_event\"}\n\n")
+	}))
+	defer ts.Close()
+
+	client := Client{baseURL: ts.URL}
+	events, unsubscribe, err := client.Events("status_update_event", "unknown_event")
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	event := <-events
+	assert.Equal(t, "status_update_event", event.Type)
+	require.IsType(t, &goMarathon.EventStatusUpdate{}, event.Event)
+	assert.Equal(t, "app.1", event.Event.(*goMarathon.EventStatusUpdate).TaskID)
+	assert.Equal(t, "TASK_RUNNING", event.Event.(*goMarathon.EventStatusUpdate).TaskStatus)
+
+	event = <-events
+	assert.Equal(t, "unknown_event", event.Type)
+	assert.JSONEq(t, `{"eventType":"unknown_event"}`, string(event.Data))
+	assert.Nil(t, event.Event)
+
+	_, ok := <-events
+	assert.False(t, ok, "expected the events channel to be closed at the end of the stream")
+}
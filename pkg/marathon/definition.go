@@ -0,0 +1,86 @@
+package marathon
+
+import (
+	"encoding/json"
+
+	"github.com/dcos/dcos-cli/api"
+)
+
+// serverManagedFields are the fields Marathon adds to app and pod definitions, they
+// can't be set by users and are thus ignored when comparing definitions.
+var serverManagedFields = []string{
+	"deployments",
+	"lastTaskFailure",
+	"readinessCheckResults",
+	"taskStats",
+	"tasks",
+	"tasksHealthy",
+	"tasksRunning",
+	"tasksStaged",
+	"tasksUnhealthy",
+	"version",
+	"versionInfo",
+}
+
+// LoadDefinition loads a JSON definition from either a file pointed to by location or an HTTP
+// URL pointed to by location or, if location is empty, from ctx.Input(). When vars is not nil,
+// the definition is rendered as a template with them first.
+func LoadDefinition(ctx api.Context, location string, vars map[string]interface{}) (map[string]interface{}, error) {
+	data, err := getAppDefinition(ctx, location)
+	if err != nil {
+		return nil, ErrCannotReadAppDefinition
+	}
+
+	if vars != nil {
+		data, err = RenderDefinition(ctx, data, vars)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var definition map[string]interface{}
+	err = json.Unmarshal(data, &definition)
+	if err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+// StripServerFields returns a copy of the given app or pod definition without
+// the fields managed by Marathon such as its version or its running tasks.
+func StripServerFields(definition map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(definition))
+	for key, value := range definition {
+		result[key] = value
+	}
+	for _, field := range serverManagedFields {
+		delete(result, field)
+	}
+	return result
+}
+
+// MergePatch applies a JSON merge patch as described in RFC 7386 to target and returns the result.
+// The target is left untouched.
+func MergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	result := make(map[string]interface{}, len(targetObject))
+	if ok {
+		for key, value := range targetObject {
+			result[key] = value
+		}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = MergePatch(result[key], value)
+	}
+	return result
+}
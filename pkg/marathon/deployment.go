@@ -0,0 +1,192 @@
+package marathon
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	goMarathon "github.com/gambol99/go-marathon"
+	"github.com/spf13/cobra"
+)
+
+// DeploymentEventTypes are the Marathon event types needed to follow a deployment.
+var DeploymentEventTypes = []string{
+	"deployment_info",
+	"deployment_step_success",
+	"deployment_step_failure",
+	"deployment_success",
+	"deployment_failed",
+	"status_update_event",
+}
+
+// Exit codes of the commands waiting for a deployment, any other error exits with 1.
+const (
+	ExitCodeDeploymentFailed   = 2
+	ExitCodeTimeout            = 3
+	ExitCodeDeploymentNotFound = 4
+)
+
+// WaitError is returned when a deployment fails or when waiting for it times out.
+type WaitError struct {
+	message  string
+	exitCode int
+}
+
+// NewTimeoutError returns a WaitError for a timeout, formatted according to a format specifier.
+func NewTimeoutError(format string, a ...interface{}) *WaitError {
+	return &WaitError{message: fmt.Sprintf(format, a...), exitCode: ExitCodeTimeout}
+}
+
+// NewDeploymentNotFoundError returns a WaitError for a deployment which is not in progress. Marathon
+// doesn't keep finished deployments, so whether it succeeded, failed or was rolled back is unknown.
+func NewDeploymentNotFoundError(deploymentID string) *WaitError {
+	return &WaitError{
+		message:  fmt.Sprintf("deployment %s is not in progress, its outcome is unknown", deploymentID),
+		exitCode: ExitCodeDeploymentNotFound,
+	}
+}
+
+func (e *WaitError) Error() string {
+	return e.message
+}
+
+// ExitCode returns the exit code telling a failed deployment, a timeout and an unknown deployment apart.
+func (e *WaitError) ExitCode() int {
+	return e.exitCode
+}
+
+// Deployment returns the deployment with the given ID or nil if it is not in progress.
+func (c *Client) Deployment(deploymentID string) (*goMarathon.Deployment, error) {
+	deployments, err := c.Deployments()
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments {
+		if deployment.ID == deploymentID {
+			return &deployment, nil
+		}
+	}
+	return nil, nil
+}
+
+// WaitOptions are the options of the commands which can wait for the deployment they create.
+type WaitOptions struct {
+	Wait    bool
+	Timeout time.Duration
+}
+
+// AddWaitFlags adds the --wait and --timeout flags to a command.
+func AddWaitFlags(cmd *cobra.Command, opts *WaitOptions) {
+	cmd.Flags().BoolVar(&opts.Wait, "wait", false, "Wait for the deployment to finish.")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 0, "Maximum time to wait, e.g. 10m (0 means no timeout).")
+}
+
+// SubscribeDeploymentEvents subscribes to DeploymentEventTypes when opts.Wait is set and returns a nil
+// channel otherwise. The returned function must be called to unsubscribe.
+func (c *Client) SubscribeDeploymentEvents(opts WaitOptions) (<-chan *Event, func(), error) {
+	if !opts.Wait {
+		return nil, func() {}, nil
+	}
+	return c.Events(DeploymentEventTypes...)
+}
+
+// WaitForDeployment follows a deployment until it finishes and writes its progress to out. The events must have
+// been subscribed to DeploymentEventTypes before the deployment was created so that none of them are missed.
+func (c *Client) WaitForDeployment(out io.Writer, events <-chan *Event, deploymentID string, timeout time.Duration) error {
+	deployment, err := c.Deployment(deploymentID)
+	if err != nil {
+		return err
+	}
+	if deployment == nil {
+		// The deployment already finished, its outcome is still to be read from the events.
+		deployment = &goMarathon.Deployment{ID: deploymentID}
+	}
+
+	fmt.Fprintf(out, "Waiting for deployment %s to finish\n", deploymentID)
+	return FollowDeployment(out, events, *deployment, timeout)
+}
+
+// FollowDeployment reads events, which must be subscribed to DeploymentEventTypes, until the given
+// deployment finishes and writes its progress to out. It returns a WaitError when the deployment fails
+// or when the timeout is reached and an error when the event stream is closed. A zero timeout means no timeout.
+func FollowDeployment(out io.Writer, events <-chan *Event, deployment goMarathon.Deployment, timeout time.Duration) error {
+	affected := make(map[string]bool)
+	for _, id := range append(deployment.AffectedApps, deployment.AffectedPods...) {
+		affected[id] = true
+	}
+
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	completedSteps := deployment.CurrentStep - 1
+	if completedSteps < 0 {
+		completedSteps = 0
+	}
+
+	for {
+		select {
+		case <-timeoutCh:
+			return NewTimeoutError("timed out waiting for deployment %s after %s", deployment.ID, timeout)
+		case event, ok := <-events:
+			if !ok {
+				return fmt.Errorf("lost connection to the Marathon event stream while watching deployment %s", deployment.ID)
+			}
+			switch e := event.Event.(type) {
+			case *goMarathon.EventDeploymentInfo:
+				if e.Plan != nil && e.Plan.ID == deployment.ID {
+					fmt.Fprintf(out, "%s Started %s\n", formatStep(completedSteps+1, deployment.TotalSteps), formatActions(e.CurrentStep))
+				}
+			case *goMarathon.EventDeploymentStepSuccess:
+				if e.Plan != nil && e.Plan.ID == deployment.ID {
+					completedSteps++
+					fmt.Fprintf(out, "%s Succeeded %s\n", formatStep(completedSteps, deployment.TotalSteps), formatActions(e.CurrentStep))
+				}
+			case *goMarathon.EventDeploymentStepFailure:
+				if e.Plan != nil && e.Plan.ID == deployment.ID {
+					fmt.Fprintf(out, "%s Failed %s\n", formatStep(completedSteps+1, deployment.TotalSteps), formatActions(e.CurrentStep))
+				}
+			case *goMarathon.EventStatusUpdate:
+				if affected[e.AppID] {
+					fmt.Fprintf(out, "  %s: task %s is %s on %s\n", e.AppID, e.TaskID, e.TaskStatus, e.Host)
+				}
+			case *goMarathon.EventDeploymentSuccess:
+				if e.ID == deployment.ID {
+					fmt.Fprintf(out, "Deployment %s succeeded\n", deployment.ID)
+					return nil
+				}
+			case *goMarathon.EventDeploymentFailed:
+				if e.ID == deployment.ID {
+					return &WaitError{message: fmt.Sprintf("deployment %s failed", deployment.ID), exitCode: ExitCodeDeploymentFailed}
+				}
+			}
+		}
+	}
+}
+
+func formatStep(step int, totalSteps int) string {
+	if totalSteps <= 0 {
+		return fmt.Sprintf("[%d]", step)
+	}
+	return fmt.Sprintf("[%d/%d]", step, totalSteps)
+}
+
+func formatActions(step *goMarathon.StepActions) string {
+	if step == nil || len(step.Actions) == 0 {
+		return "step"
+	}
+
+	actions := make([]string, 0, len(step.Actions))
+	for _, action := range step.Actions {
+		name := action.Action
+		if name == "" {
+			name = action.Type
+		}
+		actions = append(actions, fmt.Sprintf("%s %s", name, action.App))
+	}
+	return strings.Join(actions, ", ")
+}
@@ -0,0 +1,151 @@
+package marathon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// ChangeType is the type of a change between two JSON documents.
+type ChangeType string
+
+const (
+	ChangeAdded   ChangeType = "+"
+	ChangeRemoved ChangeType = "-"
+	ChangeUpdated ChangeType = "~"
+)
+
+// Change is a difference between two JSON documents.
+type Change struct {
+	Type ChangeType
+	// Path is the JSON pointer (RFC 6901) to the changed value.
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+// Diff returns the structural differences between two decoded JSON documents, ordered by path.
+func Diff(from interface{}, to interface{}) []Change {
+	var changes []Change
+	diff("", from, to, &changes)
+	return changes
+}
+
+func diff(path string, from interface{}, to interface{}, changes *[]Change) {
+	switch fromValue := from.(type) {
+	case map[string]interface{}:
+		toValue, ok := to.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make(map[string]bool)
+		for key := range fromValue {
+			keys[key] = true
+		}
+		for key := range toValue {
+			keys[key] = true
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for key := range keys {
+			sortedKeys = append(sortedKeys, key)
+		}
+		sort.Strings(sortedKeys)
+
+		for _, key := range sortedKeys {
+			keyPath := path + "/" + escapePointerToken(key)
+			oldValue, inFrom := fromValue[key]
+			newValue, inTo := toValue[key]
+			switch {
+			case !inFrom:
+				*changes = append(*changes, Change{Type: ChangeAdded, Path: keyPath, New: newValue})
+			case !inTo:
+				*changes = append(*changes, Change{Type: ChangeRemoved, Path: keyPath, Old: oldValue})
+			default:
+				diff(keyPath, oldValue, newValue, changes)
+			}
+		}
+		return
+	case []interface{}:
+		toValue, ok := to.([]interface{})
+		if !ok {
+			break
+		}
+
+		for i := 0; i < len(fromValue) || i < len(toValue); i++ {
+			indexPath := path + "/" + strconv.Itoa(i)
+			switch {
+			case i >= len(fromValue):
+				*changes = append(*changes, Change{Type: ChangeAdded, Path: indexPath, New: toValue[i]})
+			case i >= len(toValue):
+				*changes = append(*changes, Change{Type: ChangeRemoved, Path: indexPath, Old: fromValue[i]})
+			default:
+				diff(indexPath, fromValue[i], toValue[i], changes)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*changes = append(*changes, Change{Type: ChangeUpdated, Path: path, Old: from, New: to})
+	}
+}
+
+func escapePointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+// PrintDiff writes the given changes to w, one per line. Additions are printed in green,
+// removals in red and updates in yellow when colored is true.
+func PrintDiff(w io.Writer, changes []Change, colored bool) {
+	for _, change := range changes {
+		var line string
+		switch change.Type {
+		case ChangeAdded:
+			line = fmt.Sprintf("+ %s: %s", change.Path, formatJSONValue(change.New))
+		case ChangeRemoved:
+			line = fmt.Sprintf("- %s: %s", change.Path, formatJSONValue(change.Old))
+		default:
+			line = fmt.Sprintf("~ %s: %s -> %s", change.Path, formatJSONValue(change.Old), formatJSONValue(change.New))
+		}
+
+		if colored {
+			var color string
+			switch change.Type {
+			case ChangeAdded:
+				color = "32"
+			case ChangeRemoved:
+				color = "31"
+			default:
+				color = "33"
+			}
+			line = fmt.Sprintf("\033[0;%sm%s\033[0m", color, line)
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func formatJSONValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// IsColored returns whether colors should be used when writing to w,
+// this is the case on UNIX when w is a terminal.
+func IsColored(w io.Writer) bool {
+	if file, ok := w.(*os.File); ok {
+		return runtime.GOOS != "windows" && terminal.IsTerminal(int(file.Fd()))
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package marathon
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeJSON(t *testing.T, data string) interface{} {
+	var value interface{}
+	require.NoError(t, json.Unmarshal([]byte(data), &value))
+	return value
+}
+
+func TestMergePatch(t *testing.T) {
+	// Examples from RFC 7386, Appendix A.
+	testCases := []struct {
+		target string
+		patch  string
+		result string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tc := range testCases {
+		target := decodeJSON(t, tc.target)
+		result := MergePatch(target, decodeJSON(t, tc.patch))
+		assert.Equal(t, decodeJSON(t, tc.result), result, "patching %s with %s", tc.target, tc.patch)
+		assert.Equal(t, decodeJSON(t, tc.target), target, "the target must not be modified")
+	}
+}
+
+func TestStripServerFields(t *testing.T) {
+	app := map[string]interface{}{
+		"id":           "/test",
+		"version":      "2020-01-01T00:00:00.000Z",
+		"versionInfo":  map[string]interface{}{},
+		"tasksRunning": 1,
+	}
+
+	assert.Equal(t, map[string]interface{}{"id": "/test"}, StripServerFields(app))
+	assert.Len(t, app, 4)
+}
+
+func TestDiff(t *testing.T) {
+	from := decodeJSON(t, `{"id":"/test","cpus":0.1,"env":{"FOO":"bar"},"args":["a","b"],"labels":{"a/b":"c"}}`)
+	to := decodeJSON(t, `{"id":"/test","cpus":0.5,"env":{"BAZ":"qux"},"args":["a"],"labels":{"a/b":"d"},"mem":64}`)
+
+	changes := Diff(from, to)
+	assert.Equal(t, []Change{
+		{Type: ChangeRemoved, Path: "/args/1", Old: "b"},
+		{Type: ChangeUpdated, Path: "/cpus", Old: 0.1, New: 0.5},
+		{Type: ChangeAdded, Path: "/env/BAZ", New: "qux"},
+		{Type: ChangeRemoved, Path: "/env/FOO", Old: "bar"},
+		{Type: ChangeUpdated, Path: "/labels/a~1b", Old: "c", New: "d"},
+		{Type: ChangeAdded, Path: "/mem", New: float64(64)},
+	}, changes)
+
+	assert.Empty(t, Diff(from, decodeJSON(t, `{"id":"/test","cpus":0.1,"env":{"FOO":"bar"},"args":["a","b"],"labels":{"a/b":"c"}}`)))
+}
+
+func TestPrintDiff(t *testing.T) {
+	changes := []Change{
+		{Type: ChangeAdded, Path: "/mem", New: float64(64)},
+		{Type: ChangeRemoved, Path: "/env/FOO", Old: "bar"},
+		{Type: ChangeUpdated, Path: "/cpus", Old: 0.1, New: 0.5},
+	}
+
+	var out bytes.Buffer
+	PrintDiff(&out, changes, false)
+	assert.Equal(t, "+ /mem: 64\n- /env/FOO: \"bar\"\n~ /cpus: 0.1 -> 0.5\n", out.String())
+
+	out.Reset()
+	PrintDiff(&out, changes[:1], true)
+	assert.Equal(t, "\033[0;32m+ /mem: 64\033[0m\n", out.String())
+}
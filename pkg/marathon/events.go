@@ -0,0 +1,152 @@
+package marathon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	goMarathon "github.com/gambol99/go-marathon"
+)
+
+// Timestamp returns the time at which the event was emitted, as sent by Marathon.
+func (e *Event) Timestamp() string {
+	var data struct {
+		Timestamp string `json:"timestamp"`
+	}
+	json.Unmarshal(e.Data, &data)
+	return data.Timestamp
+}
+
+// AppIDs returns the IDs of the apps and pods an event relates to.
+func (e *Event) AppIDs() []string {
+	switch event := e.Event.(type) {
+	case *goMarathon.EventStatusUpdate:
+		return []string{event.AppID}
+	case *goMarathon.EventHealthCheckChanged:
+		return []string{event.AppID}
+	case *goMarathon.EventFailedHealthCheck:
+		return []string{event.AppID}
+	case *goMarathon.EventAddHealthCheck:
+		return []string{event.AppID}
+	case *goMarathon.EventRemoveHealthCheck:
+		return []string{event.AppID}
+	case *goMarathon.EventAppTerminated:
+		return []string{event.AppID}
+	case *goMarathon.EventAPIRequest:
+		if event.AppDefinition != nil {
+			return []string{event.AppDefinition.ID}
+		}
+	case *goMarathon.EventDeploymentSuccess:
+		return planAppIDs(event.Plan)
+	case *goMarathon.EventDeploymentInfo:
+		return planAppIDs(event.Plan)
+	case *goMarathon.EventDeploymentStepSuccess:
+		return planAppIDs(event.Plan)
+	case *goMarathon.EventDeploymentStepFailure:
+		return planAppIDs(event.Plan)
+	}
+
+	// Some events, such as deployment_failed, aren't fully decoded by go-marathon.
+	var data struct {
+		AppID string                     `json:"appId"`
+		Plan  *goMarathon.DeploymentPlan `json:"plan"`
+	}
+	if err := json.Unmarshal(e.Data, &data); err == nil {
+		if data.AppID != "" {
+			return []string{data.AppID}
+		}
+		return planAppIDs(data.Plan)
+	}
+	return nil
+}
+
+func planAppIDs(plan *goMarathon.DeploymentPlan) []string {
+	if plan == nil {
+		return nil
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, step := range plan.Steps {
+		if step == nil {
+			continue
+		}
+		for _, action := range step.Actions {
+			if !seen[action.App] {
+				seen[action.App] = true
+				ids = append(ids, action.App)
+			}
+		}
+	}
+	return ids
+}
+
+// Summary returns a human-readable description of the event.
+func (e *Event) Summary() string {
+	switch event := e.Event.(type) {
+	case *goMarathon.EventStatusUpdate:
+		summary := fmt.Sprintf("task %s of %s is %s on %s", event.TaskID, event.AppID, event.TaskStatus, event.Host)
+		if event.Message != "" {
+			summary += ": " + event.Message
+		}
+		return summary
+	case *goMarathon.EventHealthCheckChanged:
+		health := "unhealthy"
+		if event.Alive {
+			health = "healthy"
+		}
+		return fmt.Sprintf("task %s of %s is %s", event.TaskID, event.AppID, health)
+	case *goMarathon.EventFailedHealthCheck:
+		return fmt.Sprintf("%s health check failed for %s", event.HealthCheck.Protocol, event.AppID)
+	case *goMarathon.EventAddHealthCheck:
+		return fmt.Sprintf("%s health check added to %s", event.HealthCheck.Protocol, event.AppID)
+	case *goMarathon.EventRemoveHealthCheck:
+		return fmt.Sprintf("%s health check removed from %s", event.HealthCheck.Protocol, event.AppID)
+	case *goMarathon.EventAppTerminated:
+		return fmt.Sprintf("app %s terminated", event.AppID)
+	case *goMarathon.EventAPIRequest:
+		appID := ""
+		if event.AppDefinition != nil {
+			appID = event.AppDefinition.ID
+		}
+		return fmt.Sprintf("app %s changed through %s by %s", appID, event.URI, event.ClientIP)
+	case *goMarathon.EventGroupChangeSuccess:
+		return fmt.Sprintf("group %s changed to version %s", event.GroupID, event.Version)
+	case *goMarathon.EventGroupChangeFailed:
+		return fmt.Sprintf("change of group %s failed: %s", event.GroupID, event.Reason)
+	case *goMarathon.EventDeploymentSuccess:
+		return fmt.Sprintf("deployment %s succeeded", event.ID)
+	case *goMarathon.EventDeploymentFailed:
+		return fmt.Sprintf("deployment %s failed", event.ID)
+	case *goMarathon.EventDeploymentInfo:
+		return fmt.Sprintf("deployment %s started %s", planID(event.Plan), formatActions(event.CurrentStep))
+	case *goMarathon.EventDeploymentStepSuccess:
+		return fmt.Sprintf("deployment %s finished %s", planID(event.Plan), formatActions(event.CurrentStep))
+	case *goMarathon.EventDeploymentStepFailure:
+		return fmt.Sprintf("deployment %s failed to %s", planID(event.Plan), formatActions(event.CurrentStep))
+	case *goMarathon.EventFrameworkMessage:
+		return fmt.Sprintf("message from executor %s on agent %s: %s", event.ExecutorID, event.SlaveID, event.Message)
+	case *goMarathon.EventSubscription:
+		return fmt.Sprintf("%s subscribed %s", event.ClientIP, event.CallbackURL)
+	case *goMarathon.EventUnsubscription:
+		return fmt.Sprintf("%s unsubscribed %s", event.ClientIP, event.CallbackURL)
+	case *goMarathon.EventStreamAttached:
+		return fmt.Sprintf("%s attached to the event stream", event.RemoteAddress)
+	case *goMarathon.EventStreamDetached:
+		return fmt.Sprintf("%s detached from the event stream", event.RemoteAddress)
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, e.Data); err != nil {
+		return strings.TrimSpace(string(e.Data))
+	}
+	return compact.String()
+}
+
+func planID(plan *goMarathon.DeploymentPlan) string {
+	if plan == nil {
+		return ""
+	}
+	return plan.ID
+}
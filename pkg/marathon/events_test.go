@@ -0,0 +1,44 @@
+package marathon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSummary(t *testing.T) {
+	fixtures := []struct {
+		msg     string
+		summary string
+		appIDs  []string
+	}{
+		{
+			`{"eventType":"health_status_changed_event","appId":"/web","taskId":"web.1","alive":false}`,
+			"task web.1 of /web is unhealthy",
+			[]string{"/web"},
+		},
+		{
+			`{"eventType":"deployment_step_failure","plan":{"id":"d1","steps":[{"actions":[{"action":"StartApplication","app":"/a"},{"action":"StartApplication","app":"/b"}]}]},"currentStep":{"actions":[{"action":"StartApplication","app":"/a"}]}}`,
+			"deployment d1 failed to StartApplication /a",
+			[]string{"/a", "/b"},
+		},
+		{
+			`{"eventType":"deployment_failed","id":"d2","plan":{"id":"d2","steps":[{"actions":[{"action":"RestartApplication","app":"/c"}]}]}}`,
+			"deployment d2 failed",
+			[]string{"/c"},
+		},
+		{
+			`{"eventType":"instance_changed_event","appId":"/d", "condition":"Running"}`,
+			`{"eventType":"instance_changed_event","appId":"/d","condition":"Running"}`,
+			[]string{"/d"},
+		},
+	}
+
+	for _, fixture := range fixtures {
+		event := decodeEvent([]byte("data: " + fixture.msg + "\n"))
+		require.NotNil(t, event)
+		assert.Equal(t, fixture.summary, event.Summary())
+		assert.Equal(t, fixture.appIDs, event.AppIDs())
+	}
+}
@@ -0,0 +1,288 @@
+package marathon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// groupFileName is the name of the file holding the definition of the group a directory
+// maps to. Marathon IDs can't contain underscores, so it can't clash with an app or a pod.
+const groupFileName = "_group.json"
+
+// ExportGroup writes the definitions of the given group, as returned by RawGroup, and of all
+// its apps, pods and subgroups to dir. The layout of dir mirrors the group hierarchy: each group
+// is a directory holding its definition in _group.json along with one JSON file per app and pod.
+// Fields managed by Marathon are stripped from the definitions. The written resources are returned.
+func ExportGroup(group map[string]interface{}, dir string) ([]Resource, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	id, _ := group["id"].(string)
+	definition := groupDefinition(group)
+
+	groupResource := Resource{Kind: KindGroup, ID: id, Definition: definition, Source: filepath.Join(dir, groupFileName)}
+	if err := writeDefinition(groupResource.Source, definition); err != nil {
+		return nil, err
+	}
+	resources := []Resource{groupResource}
+
+	for field, kind := range map[string]ResourceKind{"apps": KindApp, "pods": KindPod} {
+		definitions, _ := group[field].([]interface{})
+		for _, definition := range definitions {
+			definition, ok := definition.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			id, _ := definition["id"].(string)
+			resource := Resource{
+				Kind:       kind,
+				ID:         id,
+				Definition: StripServerFields(definition),
+				Source:     filepath.Join(dir, path.Base(id)+".json"),
+			}
+			if err := writeDefinition(resource.Source, resource.Definition); err != nil {
+				return nil, err
+			}
+			resources = append(resources, resource)
+		}
+	}
+
+	groups, _ := group["groups"].([]interface{})
+	for _, subgroup := range groups {
+		subgroup, ok := subgroup.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := subgroup["id"].(string)
+		subgroupResources, err := ExportGroup(subgroup, filepath.Join(dir, path.Base(id)))
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, subgroupResources...)
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		return resources[i].Source < resources[j].Source
+	})
+	return resources, nil
+}
+
+func writeDefinition(file string, definition map[string]interface{}) error {
+	data, err := json.MarshalIndent(definition, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, append(data, '\n'), 0644)
+}
+
+// LoadExport loads the groups, apps and pods exported to dir by ExportGroup and returns them
+// in the order they should be created: groups from the outermost to the innermost, then apps
+// and pods after the ones they depend on, either directly or through their groups.
+func LoadExport(dir string) ([]Resource, error) {
+	var groups, items []Resource
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.EqualFold(filepath.Ext(file), ".json") {
+			return nil
+		}
+
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		var definition map[string]interface{}
+		if err := json.Unmarshal(data, &definition); err != nil {
+			return fmt.Errorf("error loading JSON from %s: %s", file, err)
+		}
+		id, ok := definition["id"].(string)
+		if !ok || id == "" {
+			return fmt.Errorf("invalid definition in %s: missing ID", file)
+		}
+
+		resource := Resource{Kind: KindApp, ID: NormalizeAppID(id), Definition: definition, Source: file}
+		switch {
+		case filepath.Base(file) == groupFileName:
+			resource.Kind = KindGroup
+			groups = append(groups, resource)
+		case definition["containers"] != nil:
+			resource.Kind = KindPod
+			items = append(items, resource)
+		default:
+			items = append(items, resource)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		di, dj := strings.Count(groups[i].ID, "/"), strings.Count(groups[j].ID, "/")
+		if di != dj {
+			return di < dj
+		}
+		return groups[i].ID < groups[j].ID
+	})
+
+	items, err = sortByDependencies(items, groups)
+	if err != nil {
+		return nil, err
+	}
+	return append(groups, items...), nil
+}
+
+// sortByDependencies sorts apps and pods so that each of them comes after the ones it depends on.
+func sortByDependencies(items []Resource, groups []Resource) ([]Resource, error) {
+	// dependents maps the index of an item to the indexes of the items depending on it.
+	dependents := itemDependents(items, groups)
+	pending := make([]int, len(items))
+	for _, indexes := range dependents {
+		for _, i := range indexes {
+			pending[i]++
+		}
+	}
+
+	var sorted []Resource
+	done := make([]bool, len(items))
+	for len(sorted) < len(items) {
+		var ready []int
+		for i := range items {
+			if !done[i] && pending[i] == 0 {
+				ready = append(ready, i)
+			}
+		}
+		if len(ready) == 0 {
+			var cycle []string
+			for i, item := range items {
+				if !done[i] {
+					cycle = append(cycle, item.ID)
+				}
+			}
+			sort.Strings(cycle)
+			return nil, fmt.Errorf("circular dependency between %s", strings.Join(cycle, ", "))
+		}
+
+		sort.Slice(ready, func(a, b int) bool {
+			return items[ready[a]].ID < items[ready[b]].ID
+		})
+		for _, i := range ready {
+			done[i] = true
+			sorted = append(sorted, items[i])
+			for _, dependent := range dependents[i] {
+				pending[dependent]--
+			}
+		}
+	}
+	return sorted, nil
+}
+
+// DependedOn returns the IDs of the apps and pods of resources which other apps or pods depend on,
+// either directly or through the dependencies of their groups.
+func DependedOn(resources []Resource) map[string]bool {
+	var items, groups []Resource
+	for _, resource := range resources {
+		if resource.Kind == KindGroup {
+			groups = append(groups, resource)
+		} else {
+			items = append(items, resource)
+		}
+	}
+
+	result := make(map[string]bool)
+	for i := range itemDependents(items, groups) {
+		result[items[i].ID] = true
+	}
+	return result
+}
+
+// itemDependents maps the index of each app or pod in items to the indexes of the ones depending on it.
+func itemDependents(items []Resource, groups []Resource) map[int][]int {
+	groupDependencies := make(map[string][]string)
+	for _, group := range groups {
+		groupDependencies[group.ID] = dependencies(group)
+	}
+
+	dependents := make(map[int][]int)
+	for i, item := range items {
+		deps := dependencies(item)
+		for groupID := path.Dir(item.ID); ; groupID = path.Dir(groupID) {
+			deps = append(deps, groupDependencies[groupID]...)
+			if groupID == "/" {
+				break
+			}
+		}
+
+		for j, other := range items {
+			if i == j {
+				continue
+			}
+			for _, dep := range deps {
+				if other.ID == dep || strings.HasPrefix(other.ID, strings.TrimSuffix(dep, "/")+"/") {
+					dependents[j] = append(dependents[j], i)
+					break
+				}
+			}
+		}
+	}
+	return dependents
+}
+
+// dependencies returns the absolute IDs of the dependencies of a resource.
+func dependencies(resource Resource) []string {
+	values, _ := resource.Definition["dependencies"].([]interface{})
+	parentID := path.Dir(resource.ID)
+	if resource.Kind == KindGroup && resource.ID == "/" {
+		parentID = "/"
+	}
+
+	var result []string
+	for _, value := range values {
+		if dep, ok := value.(string); ok && dep != "" {
+			result = append(result, resolveID(parentID, dep))
+		}
+	}
+	return result
+}
+
+// ExistingResources returns the kind of all the groups, apps and pods deployed in Marathon, by ID.
+func (c *Client) ExistingResources() (map[string]ResourceKind, error) {
+	root, err := c.RawGroup("/")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]ResourceKind)
+	collectExistingResources(root, result)
+	return result, nil
+}
+
+func collectExistingResources(group map[string]interface{}, result map[string]ResourceKind) {
+	if id, ok := group["id"].(string); ok {
+		result[NormalizeAppID(id)] = KindGroup
+	}
+	for field, kind := range map[string]ResourceKind{"apps": KindApp, "pods": KindPod} {
+		definitions, _ := group[field].([]interface{})
+		for _, definition := range definitions {
+			if definition, ok := definition.(map[string]interface{}); ok {
+				id, _ := definition["id"].(string)
+				result[NormalizeAppID(id)] = kind
+			}
+		}
+	}
+
+	groups, _ := group["groups"].([]interface{})
+	for _, subgroup := range groups {
+		if subgroup, ok := subgroup.(map[string]interface{}); ok {
+			collectExistingResources(subgroup, result)
+		}
+	}
+}
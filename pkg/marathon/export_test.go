@@ -0,0 +1,89 @@
+package marathon
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exportedTree = `{"id":"/prod","dependencies":[],"version":"2020-01-01T00:00:00.000Z",
+	"apps":[
+		{"id":"/prod/web","cmd":"sleep 100","dependencies":["backend"],"tasksRunning":2,"version":"2020-01-01T00:00:00.000Z"}
+	],
+	"pods":[{"id":"/prod/cache","containers":[{"name":"redis"}]}],
+	"groups":[
+		{"id":"/prod/backend","dependencies":["/prod/db"],"apps":[{"id":"/prod/backend/api","cmd":"sleep 100","deployments":[]}],"pods":[],"groups":[]},
+		{"id":"/prod/db","apps":[{"id":"/prod/db/postgres","cmd":"sleep 100"}],"pods":[],"groups":[]}
+	]}`
+
+func TestExportGroup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "marathon-export")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var group map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(exportedTree), &group))
+
+	resources, err := ExportGroup(group, dir)
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, resource := range resources {
+		rel, err := filepath.Rel(dir, resource.Source)
+		require.NoError(t, err)
+		files[filepath.ToSlash(rel)] = resource.ID
+	}
+	assert.Equal(t, map[string]string{
+		"_group.json":         "/prod",
+		"web.json":            "/prod/web",
+		"cache.json":          "/prod/cache",
+		"backend/_group.json": "/prod/backend",
+		"backend/api.json":    "/prod/backend/api",
+		"db/_group.json":      "/prod/db",
+		"db/postgres.json":    "/prod/db/postgres",
+	}, files)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "web.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "{\n    \"cmd\": \"sleep 100\",\n    \"dependencies\": [\n        \"backend\"\n    ],\n    \"id\": \"/prod/web\"\n}\n", string(data))
+
+	data, err = ioutil.ReadFile(filepath.Join(dir, "_group.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"/prod","dependencies":[]}`, string(data))
+
+	loaded, err := LoadExport(dir)
+	require.NoError(t, err)
+
+	var order []string
+	for _, resource := range loaded {
+		order = append(order, string(resource.Kind)+" "+resource.ID)
+	}
+	assert.Equal(t, []string{
+		"group /prod",
+		"group /prod/backend",
+		"group /prod/db",
+		"pod /prod/cache",
+		"app /prod/db/postgres",
+		"app /prod/backend/api",
+		"app /prod/web",
+	}, order)
+	assert.Equal(t, map[string]bool{"/prod/db/postgres": true, "/prod/backend/api": true}, DependedOn(loaded))
+}
+
+func TestLoadExportCircularDependency(t *testing.T) {
+	dir := writeDefinitions(t, map[string]string{
+		"_group.json": `{"id":"/"}`,
+		"a.json":      `{"id":"/a","dependencies":["/b"]}`,
+		"b.json":      `{"id":"/b","dependencies":["/a"]}`,
+		"c.json":      `{"id":"/c"}`,
+	})
+	defer os.RemoveAll(dir)
+
+	_, err := LoadExport(dir)
+	assert.EqualError(t, err, "circular dependency between /a, /b")
+}
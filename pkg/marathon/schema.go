@@ -0,0 +1,416 @@
+package marathon
+
+// appSchema is the JSON schema of Marathon app definitions, it covers the fields of the Marathon 1.10 API.
+const appSchema = `{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "type": "object",
+  "required": ["id"],
+  "additionalProperties": false,
+  "properties": {
+    "id": {"$ref": "#/definitions/pathId"},
+    "cmd": {"type": "string", "minLength": 1},
+    "args": {"type": "array", "items": {"type": "string"}},
+    "user": {"type": "string"},
+    "env": {"$ref": "#/definitions/envVars"},
+    "instances": {"type": "integer", "minimum": 0},
+    "cpus": {"type": "number", "minimum": 0},
+    "mem": {"type": "number", "minimum": 0},
+    "disk": {"type": "number", "minimum": 0},
+    "gpus": {"type": "integer", "minimum": 0},
+    "executor": {"type": "string"},
+    "constraints": {"$ref": "#/definitions/constraints"},
+    "acceptedResourceRoles": {"type": "array", "items": {"type": "string"}},
+    "role": {"type": "string"},
+    "fetch": {"type": "array", "items": {"$ref": "#/definitions/artifact"}},
+    "uris": {"type": "array", "items": {"type": "string"}},
+    "storeUrls": {"type": "array", "items": {"type": "string"}},
+    "ports": {"type": "array", "items": {"type": "integer", "minimum": 0, "maximum": 65535}},
+    "portDefinitions": {"type": "array", "items": {"$ref": "#/definitions/portDefinition"}},
+    "requirePorts": {"type": "boolean"},
+    "backoffSeconds": {"type": "number", "minimum": 0},
+    "backoffFactor": {"type": "number", "minimum": 1},
+    "maxLaunchDelaySeconds": {"type": "number", "minimum": 0},
+    "container": {"$ref": "#/definitions/container"},
+    "healthChecks": {"type": "array", "items": {"$ref": "#/definitions/healthCheck"}},
+    "check": {"$ref": "#/definitions/check"},
+    "readinessChecks": {"type": "array", "items": {"$ref": "#/definitions/readinessCheck"}},
+    "dependencies": {"type": "array", "items": {"$ref": "#/definitions/pathId"}},
+    "upgradeStrategy": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "minimumHealthCapacity": {"type": "number", "minimum": 0, "maximum": 1},
+        "maximumOverCapacity": {"type": "number", "minimum": 0, "maximum": 1}
+      }
+    },
+    "labels": {"$ref": "#/definitions/stringMap"},
+    "killSelection": {"enum": ["YOUNGEST_FIRST", "OLDEST_FIRST"]},
+    "unreachableStrategy": {"$ref": "#/definitions/unreachableStrategy"},
+    "secrets": {"$ref": "#/definitions/secrets"},
+    "networks": {"type": "array", "items": {"$ref": "#/definitions/network"}},
+    "residency": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "relaunchEscalationTimeoutSeconds": {"type": "integer", "minimum": 0},
+        "taskLostBehavior": {"enum": ["WAIT_FOREVER", "RELAUNCH_AFTER_TIMEOUT"]}
+      }
+    },
+    "taskKillGracePeriodSeconds": {"type": "number", "minimum": 0},
+    "ipAddress": {"type": "object"},
+    "resourceLimits": {"type": "object"},
+    "tty": {"type": "boolean"},
+    "version": {"type": "string"},
+    "versionInfo": {"type": "object"},
+    "deployments": {"type": "array"},
+    "lastTaskFailure": {"type": "object"},
+    "readinessCheckResults": {"type": "array"},
+    "tasks": {"type": "array"},
+    "taskStats": {"type": "object"},
+    "tasksHealthy": {"type": "integer"},
+    "tasksRunning": {"type": "integer"},
+    "tasksStaged": {"type": "integer"},
+    "tasksUnhealthy": {"type": "integer"}
+  },
+  "definitions": {
+    "pathId": {
+      "type": "string",
+      "pattern": "^(\\/?((\\.\\.)|(([a-z0-9]|[a-z0-9][a-z0-9\\-]*[a-z0-9])\\.)*([a-z0-9]|[a-z0-9][a-z0-9\\-]*[a-z0-9]))?($|\\/))+$"
+    },
+    "stringMap": {"type": "object", "additionalProperties": {"type": "string"}},
+    "envVars": {
+      "type": "object",
+      "additionalProperties": {
+        "type": ["string", "object"],
+        "additionalProperties": false,
+        "properties": {"secret": {"type": "string"}}
+      }
+    },
+    "secrets": {
+      "type": "object",
+      "additionalProperties": {
+        "type": "object",
+        "required": ["source"],
+        "additionalProperties": false,
+        "properties": {"source": {"type": "string"}}
+      }
+    },
+    "constraints": {"type": "array", "items": {"type": "array", "items": {"type": "string"}}},
+    "artifact": {
+      "type": "object",
+      "required": ["uri"],
+      "additionalProperties": false,
+      "properties": {
+        "uri": {"type": "string"},
+        "executable": {"type": "boolean"},
+        "extract": {"type": "boolean"},
+        "cache": {"type": "boolean"},
+        "destPath": {"type": "string"}
+      }
+    },
+    "portDefinition": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "port": {"type": "integer", "minimum": 0, "maximum": 65535},
+        "protocol": {"enum": ["tcp", "udp", "udp,tcp", "tcp,udp"]},
+        "name": {"type": "string"},
+        "labels": {"$ref": "#/definitions/stringMap"}
+      }
+    },
+    "portMapping": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "containerPort": {"type": "integer", "minimum": 0, "maximum": 65535},
+        "hostPort": {"type": "integer", "minimum": 0, "maximum": 65535},
+        "servicePort": {"type": "integer", "minimum": 0, "maximum": 65535},
+        "protocol": {"enum": ["tcp", "udp", "udp,tcp", "tcp,udp"]},
+        "name": {"type": "string"},
+        "labels": {"$ref": "#/definitions/stringMap"},
+        "networkNames": {"type": "array", "items": {"type": "string"}}
+      }
+    },
+    "network": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "name": {"type": "string"},
+        "mode": {"enum": ["container", "container/bridge", "host"]},
+        "labels": {"$ref": "#/definitions/stringMap"}
+      }
+    },
+    "container": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "type": {"enum": ["DOCKER", "MESOS"]},
+        "docker": {
+          "type": "object",
+          "required": ["image"],
+          "additionalProperties": false,
+          "properties": {
+            "image": {"type": "string", "minLength": 1},
+            "forcePullImage": {"type": "boolean"},
+            "privileged": {"type": "boolean"},
+            "parameters": {
+              "type": "array",
+              "items": {
+                "type": "object",
+                "required": ["key", "value"],
+                "additionalProperties": false,
+                "properties": {"key": {"type": "string"}, "value": {"type": "string"}}
+              }
+            },
+            "pullConfig": {"type": "object"},
+            "credential": {"type": "object"},
+            "network": {"enum": ["BRIDGE", "HOST", "NONE", "USER"]},
+            "portMappings": {"type": "array", "items": {"$ref": "#/definitions/portMapping"}}
+          }
+        },
+        "appc": {"type": "object"},
+        "volumes": {"type": "array", "items": {"type": "object"}},
+        "portMappings": {"type": "array", "items": {"$ref": "#/definitions/portMapping"}},
+        "linuxInfo": {"type": "object"}
+      }
+    },
+    "healthCheck": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "protocol": {"enum": ["HTTP", "HTTPS", "TCP", "COMMAND", "MESOS_HTTP", "MESOS_HTTPS", "MESOS_TCP"]},
+        "ipProtocol": {"enum": ["IPv4", "IPv6"]},
+        "path": {"type": "string"},
+        "port": {"type": "integer", "minimum": 0, "maximum": 65535},
+        "portIndex": {"type": "integer", "minimum": 0},
+        "command": {
+          "type": "object",
+          "required": ["value"],
+          "additionalProperties": false,
+          "properties": {"value": {"type": "string"}}
+        },
+        "gracePeriodSeconds": {"type": "integer", "minimum": 0},
+        "intervalSeconds": {"type": "integer", "minimum": 0},
+        "timeoutSeconds": {"type": "integer", "minimum": 0},
+        "maxConsecutiveFailures": {"type": "integer", "minimum": 0},
+        "ignoreHttp1xx": {"type": "boolean"},
+        "delaySeconds": {"type": "integer", "minimum": 0}
+      }
+    },
+    "check": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "http": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "portIndex": {"type": "integer", "minimum": 0},
+            "path": {"type": "string"},
+            "scheme": {"enum": ["HTTP"]}
+          }
+        },
+        "tcp": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {"portIndex": {"type": "integer", "minimum": 0}}
+        },
+        "exec": {
+          "type": "object",
+          "required": ["command"],
+          "additionalProperties": false,
+          "properties": {"command": {"type": "object"}}
+        },
+        "intervalSeconds": {"type": "integer", "minimum": 0},
+        "timeoutSeconds": {"type": "integer", "minimum": 0},
+        "delaySeconds": {"type": "integer", "minimum": 0}
+      }
+    },
+    "readinessCheck": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "name": {"type": "string"},
+        "protocol": {"enum": ["HTTP", "HTTPS"]},
+        "path": {"type": "string"},
+        "portName": {"type": "string"},
+        "intervalSeconds": {"type": "integer", "minimum": 0},
+        "timeoutSeconds": {"type": "integer", "minimum": 0},
+        "httpStatusCodesForReady": {"type": "array", "items": {"type": "integer"}},
+        "preserveLastResponse": {"type": "boolean"}
+      }
+    },
+    "unreachableStrategy": {
+      "type": ["string", "object"],
+      "additionalProperties": false,
+      "properties": {
+        "inactiveAfterSeconds": {"type": "integer", "minimum": 0},
+        "expungeAfterSeconds": {"type": "integer", "minimum": 0}
+      }
+    }
+  }
+}`
+
+// podSchema is the JSON schema of Marathon pod definitions, it covers the fields of the Marathon 1.10 API.
+const podSchema = `{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "type": "object",
+  "required": ["id", "containers"],
+  "additionalProperties": false,
+  "properties": {
+    "id": {
+      "type": "string",
+      "pattern": "^(\\/?((\\.\\.)|(([a-z0-9]|[a-z0-9][a-z0-9\\-]*[a-z0-9])\\.)*([a-z0-9]|[a-z0-9][a-z0-9\\-]*[a-z0-9]))?($|\\/))+$"
+    },
+    "labels": {"$ref": "#/definitions/stringMap"},
+    "version": {"type": "string"},
+    "user": {"type": "string"},
+    "environment": {"$ref": "#/definitions/envVars"},
+    "containers": {"type": "array", "items": {"$ref": "#/definitions/container"}},
+    "secrets": {"type": "object"},
+    "volumes": {"type": "array", "items": {"type": "object"}},
+    "networks": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "properties": {
+          "name": {"type": "string"},
+          "mode": {"enum": ["container", "container/bridge", "host"]},
+          "labels": {"$ref": "#/definitions/stringMap"}
+        }
+      }
+    },
+    "scaling": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "kind": {"enum": ["fixed"]},
+        "instances": {"type": "integer", "minimum": 0},
+        "maxInstances": {"type": "integer", "minimum": 0}
+      }
+    },
+    "scheduling": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "backoff": {"type": "object"},
+        "upgrade": {"type": "object"},
+        "placement": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "constraints": {"type": "array", "items": {"type": "object"}},
+            "acceptedResourceRoles": {"type": "array", "items": {"type": "string"}}
+          }
+        },
+        "killSelection": {"enum": ["YOUNGEST_FIRST", "OLDEST_FIRST"]},
+        "unreachableStrategy": {"type": ["string", "object"]}
+      }
+    },
+    "executorResources": {"$ref": "#/definitions/resources"},
+    "legacySharedCgroups": {"type": "boolean"},
+    "linuxInfo": {"type": "object"},
+    "role": {"type": "string"}
+  },
+  "definitions": {
+    "stringMap": {"type": "object", "additionalProperties": {"type": "string"}},
+    "envVars": {
+      "type": "object",
+      "additionalProperties": {
+        "type": ["string", "object"],
+        "additionalProperties": false,
+        "properties": {"secret": {"type": "string"}}
+      }
+    },
+    "resources": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "cpus": {"type": "number", "minimum": 0},
+        "mem": {"type": "number", "minimum": 0},
+        "disk": {"type": "number", "minimum": 0},
+        "gpus": {"type": "integer", "minimum": 0}
+      }
+    },
+    "container": {
+      "type": "object",
+      "required": ["name", "resources"],
+      "additionalProperties": false,
+      "properties": {
+        "name": {"type": "string", "pattern": "^[a-z0-9]([a-z0-9-]*[a-z0-9]+)*$"},
+        "exec": {
+          "type": "object",
+          "required": ["command"],
+          "additionalProperties": false,
+          "properties": {
+            "command": {
+              "type": "object",
+              "additionalProperties": false,
+              "properties": {
+                "shell": {"type": "string"},
+                "argv": {"type": "array", "items": {"type": "string"}}
+              }
+            },
+            "overrideEntrypoint": {"type": "boolean"}
+          }
+        },
+        "resources": {"$ref": "#/definitions/resources"},
+        "resourceLimits": {"type": "object"},
+        "endpoints": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "required": ["name"],
+            "additionalProperties": false,
+            "properties": {
+              "name": {"type": "string"},
+              "containerPort": {"type": "integer", "minimum": 0, "maximum": 65535},
+              "hostPort": {"type": "integer", "minimum": 0, "maximum": 65535},
+              "protocol": {"type": "array", "items": {"enum": ["tcp", "udp"]}},
+              "labels": {"$ref": "#/definitions/stringMap"},
+              "networkNames": {"type": "array", "items": {"type": "string"}}
+            }
+          }
+        },
+        "image": {
+          "type": "object",
+          "required": ["kind", "id"],
+          "additionalProperties": false,
+          "properties": {
+            "kind": {"enum": ["DOCKER", "APPC"]},
+            "id": {"type": "string", "minLength": 1},
+            "forcePull": {"type": "boolean"},
+            "pullConfig": {"type": "object"}
+          }
+        },
+        "environment": {"$ref": "#/definitions/envVars"},
+        "user": {"type": "string"},
+        "healthCheck": {"type": "object"},
+        "check": {"type": "object"},
+        "volumeMounts": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "required": ["name", "mountPath"],
+            "additionalProperties": false,
+            "properties": {
+              "name": {"type": "string"},
+              "mountPath": {"type": "string"},
+              "readOnly": {"type": "boolean"}
+            }
+          }
+        },
+        "artifacts": {"type": "array", "items": {"type": "object"}},
+        "labels": {"$ref": "#/definitions/stringMap"},
+        "lifecycle": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {"killGracePeriodSeconds": {"type": "number", "minimum": 0}}
+        },
+        "linuxInfo": {"type": "object"},
+        "tty": {"type": "boolean"}
+      }
+    }
+  }
+}`
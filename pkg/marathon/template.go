@@ -0,0 +1,84 @@
+package marathon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"text/template"
+
+	"github.com/dcos/dcos-cli/api"
+	"gopkg.in/yaml.v3"
+)
+
+// TemplateVars returns the variables to render definition templates with. They are loaded from
+// the YAML or JSON file varFile, if set, and overridden by vars in the form key=value.
+// It returns nil when neither are given, meaning that definitions shouldn't be rendered.
+func TemplateVars(varFile string, vars []string) (map[string]interface{}, error) {
+	if varFile == "" && len(vars) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[string]interface{})
+	if varFile != "" {
+		data, err := ioutil.ReadFile(varFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't read variables file: %s", err)
+		}
+		if err := yaml.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("error loading variables from %s: %s", varFile, err)
+		}
+		if result == nil {
+			result = make(map[string]interface{})
+		}
+	}
+
+	for _, v := range vars {
+		terms := strings.SplitN(v, "=", 2)
+		if len(terms) != 2 || terms[0] == "" {
+			return nil, fmt.Errorf("'%s' is not a valid variable, expected key=value", v)
+		}
+		result[terms[0]] = terms[1]
+	}
+	return result, nil
+}
+
+// RenderDefinition renders a definition as a Go template with the given variables. Besides the
+// variables, accessible as {{ .name }}, templates can use the following functions:
+//
+//	env "NAME"               the value of an environment variable, or an empty string if it isn't set
+//	default "name" "value"   the value of the variable name, or value when it isn't set or is empty
+//	json .name               the JSON encoding of a value
+//
+// An error is returned when the template uses a variable which isn't set, variables which may
+// not be set must be accessed through default.
+func RenderDefinition(ctx api.Context, data []byte, vars map[string]interface{}) ([]byte, error) {
+	funcs := template.FuncMap{
+		"env": func(key string) string {
+			value, _ := ctx.EnvLookup(key)
+			return value
+		},
+		"default": func(key string, defaultValue interface{}) interface{} {
+			if value, ok := vars[key]; ok && value != nil && value != "" {
+				return value
+			}
+			return defaultValue
+		},
+		"json": func(value interface{}) (string, error) {
+			data, err := json.Marshal(value)
+			return string(data), err
+		},
+	}
+
+	tmpl, err := template.New("definition").Funcs(funcs).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing template: %s", err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, vars); err != nil {
+		return nil, fmt.Errorf("error rendering template: %s", err)
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,81 @@
+package marathon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateVars(t *testing.T) {
+	vars, err := TemplateVars("", nil)
+	require.NoError(t, err)
+	assert.Nil(t, vars)
+
+	dir, err := ioutil.TempDir("", "template-vars")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	varFile := filepath.Join(dir, "vars.yaml")
+	err = ioutil.WriteFile(varFile, []byte("env: staging\ninstances: 2\nlabels:\n  team: infra\n"), 0600)
+	require.NoError(t, err)
+
+	vars, err = TemplateVars(varFile, []string{"env=prod", "image=nginx:1.19=latest"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"env":       "prod",
+		"image":     "nginx:1.19=latest",
+		"instances": 2,
+		"labels":    map[string]interface{}{"team": "infra"},
+	}, vars)
+
+	_, err = TemplateVars("", []string{"env"})
+	assert.EqualError(t, err, "'env' is not a valid variable, expected key=value")
+}
+
+func TestRenderDefinition(t *testing.T) {
+	env := mock.NewEnvironment()
+	env.EnvLookup = func(key string) (string, bool) {
+		if key == "REGISTRY" {
+			return "registry.example.com", true
+		}
+		return "", false
+	}
+	ctx := mock.NewContext(env)
+
+	definition := `{"id":"/{{ .env }}/web","instances":{{ default "instances" 1 }},` +
+		`"container":{"docker":{"image":"{{ env "REGISTRY" }}/web:{{ default "tag" "latest" }}"}},"labels":{{ json .labels }}}`
+
+	data, err := RenderDefinition(ctx, []byte(definition), map[string]interface{}{
+		"env":    "prod",
+		"tag":    "",
+		"labels": map[string]interface{}{"team": "infra"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"/prod/web","instances":1,`+
+		`"container":{"docker":{"image":"registry.example.com/web:latest"}},"labels":{"team":"infra"}}`, string(data))
+}
+
+func TestRenderDefinitionErrors(t *testing.T) {
+	ctx := mock.NewContext(mock.NewEnvironment())
+
+	_, err := RenderDefinition(ctx, []byte(`{"id":"/{{ .env }}/web"}`), map[string]interface{}{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `map has no entry for key "env"`)
+
+	// Missing variables are detected even when they aren't printed.
+	_, err = RenderDefinition(ctx, []byte(`{"id":"/web"{{ if .debug }},"cmd":"debug"{{ end }}}`), map[string]interface{}{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `map has no entry for key "debug"`)
+
+	data, err := RenderDefinition(ctx, []byte(`{"id":"/web","cmd":"echo <no value>"}`), map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"/web","cmd":"echo <no value>"}`, string(data))
+
+	_, err = RenderDefinition(ctx, []byte(`{"id":"/{{ .env /web"}`), map[string]interface{}{})
+	assert.Error(t, err)
+}
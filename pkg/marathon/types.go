@@ -1,5 +1,11 @@
 package marathon
 
+import (
+	"encoding/json"
+
+	goMarathon "github.com/gambol99/go-marathon"
+)
+
 // Groups represents a stripped down version of the Marathon groups
 type Groups struct {
 	Groups []struct {
@@ -11,3 +17,99 @@ type Groups struct {
 type RawQueue struct {
 	Queue []map[string]interface{} `json:"queue"`
 }
+
+// Group represents a Marathon group along with its nested apps, pods and groups. It keeps the
+// JSON it has been decoded from, which holds fields unknown to go-marathon, and is encoded back to it.
+type Group struct {
+	ID           string                   `json:"id"`
+	Apps         []goMarathon.Application `json:"apps"`
+	Pods         []goMarathon.Pod         `json:"pods"`
+	Groups       []Group                  `json:"groups"`
+	Dependencies []string                 `json:"dependencies"`
+	EnforceRole  bool                     `json:"enforceRole"`
+	Version      string                   `json:"version,omitempty"`
+
+	raw json.RawMessage
+}
+
+// groupFields has the fields of Group without its JSON methods.
+type groupFields Group
+
+// UnmarshalJSON decodes a group and keeps its JSON representation.
+func (g *Group) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*groupFields)(g)); err != nil {
+		return err
+	}
+	g.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// MarshalJSON returns the JSON representation of the group as sent by Marathon.
+func (g Group) MarshalJSON() ([]byte, error) {
+	if g.raw != nil {
+		return g.raw, nil
+	}
+	return json.Marshal(groupFields(g))
+}
+
+// AppCount returns the number of apps in the group, including the ones in its nested groups.
+func (g Group) AppCount() int {
+	count := len(g.Apps)
+	for _, group := range g.Groups {
+		count += group.AppCount()
+	}
+	return count
+}
+
+// Event is an event received from the Marathon event stream.
+type Event struct {
+	Type string
+	Data json.RawMessage
+
+	// Event holds the decoded event, e.g. a *goMarathon.EventStatusUpdate.
+	// It is nil for event types unknown to the client.
+	Event interface{}
+}
+
+// Task is a Marathon task. It keeps the JSON it has been decoded from, which holds
+// fields unknown to go-marathon, and is encoded back to it.
+type Task struct {
+	goMarathon.Task
+	raw json.RawMessage
+}
+
+// UnmarshalJSON decodes a task and keeps its JSON representation.
+func (t *Task) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &t.Task); err != nil {
+		return err
+	}
+	t.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// MarshalJSON returns the JSON representation of the task as sent by Marathon.
+func (t Task) MarshalJSON() ([]byte, error) {
+	if t.raw != nil {
+		return t.raw, nil
+	}
+	return json.Marshal(t.Task)
+}
+
+// Health returns "healthy" when all the health checks of the task pass, "unhealthy" when one
+// of them fails and "unknown" when the task has no health check result.
+func (t Task) Health() string {
+	if !t.HasHealthCheckResults() {
+		return "unknown"
+	}
+	for _, result := range t.HealthCheckResults {
+		if result == nil || !result.Alive {
+			return "unhealthy"
+		}
+	}
+	return "healthy"
+}
+
+// Plugins represents the plugins returned by /v2/plugins.
+type Plugins struct {
+	Plugins []map[string]interface{} `json:"plugins"`
+}
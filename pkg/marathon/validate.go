@@ -0,0 +1,354 @@
+package marathon
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ValidationError is a problem found in a definition.
+type ValidationError struct {
+	// Pointer is the JSON pointer (RFC 6901) to the invalid value.
+	Pointer string
+	Message string
+}
+
+func (e ValidationError) String() string {
+	pointer := e.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	return fmt.Sprintf("%s: %s", pointer, e.Message)
+}
+
+// jsonSchema is the subset of JSON schema draft 4 used by the embedded Marathon schemas.
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 schemaTypes            `json:"type"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties *additionalProperties  `json:"additionalProperties"`
+	Required             []string               `json:"required"`
+	Items                *jsonSchema            `json:"items"`
+	Enum                 []interface{}          `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	MinLength            *int                   `json:"minLength"`
+	Pattern              string                 `json:"pattern"`
+	Definitions          map[string]*jsonSchema `json:"definitions"`
+}
+
+// schemaTypes is the value of the type keyword, which is either a type or a list of types.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var multiple []string
+	err := json.Unmarshal(data, &multiple)
+	*t = multiple
+	return err
+}
+
+// additionalProperties is the value of the additionalProperties keyword, which is either a boolean or a schema.
+type additionalProperties struct {
+	allowed bool
+	schema  *jsonSchema
+}
+
+func (a *additionalProperties) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.allowed); err == nil {
+		return nil
+	}
+	a.allowed = true
+	return json.Unmarshal(data, &a.schema)
+}
+
+var (
+	schemasOnce   sync.Once
+	appJSONSchema *jsonSchema
+	podJSONSchema *jsonSchema
+)
+
+func loadSchemas() {
+	schemasOnce.Do(func() {
+		appJSONSchema = mustParseSchema(appSchema)
+		podJSONSchema = mustParseSchema(podSchema)
+	})
+}
+
+func mustParseSchema(data string) *jsonSchema {
+	var schema jsonSchema
+	if err := json.Unmarshal([]byte(data), &schema); err != nil {
+		panic(fmt.Sprintf("invalid embedded schema: %s", err))
+	}
+	return &schema
+}
+
+// ValidateApp checks a decoded app definition against the Marathon app schema and for common mistakes.
+// The returned errors are ordered by JSON pointer.
+func ValidateApp(definition interface{}) []ValidationError {
+	loadSchemas()
+
+	v := validator{root: appJSONSchema}
+	v.validate("", definition, appJSONSchema)
+	if app, ok := definition.(map[string]interface{}); ok {
+		v.errors = append(v.errors, checkApp(app)...)
+	}
+	return sortValidationErrors(v.errors)
+}
+
+// ValidatePod checks a decoded pod definition against the Marathon pod schema and for common mistakes.
+// The returned errors are ordered by JSON pointer.
+func ValidatePod(definition interface{}) []ValidationError {
+	loadSchemas()
+
+	v := validator{root: podJSONSchema}
+	v.validate("", definition, podJSONSchema)
+	if pod, ok := definition.(map[string]interface{}); ok {
+		v.errors = append(v.errors, checkPod(pod)...)
+	}
+	return sortValidationErrors(v.errors)
+}
+
+func sortValidationErrors(errors []ValidationError) []ValidationError {
+	sort.SliceStable(errors, func(i, j int) bool {
+		return errors[i].Pointer < errors[j].Pointer
+	})
+	return errors
+}
+
+type validator struct {
+	root   *jsonSchema
+	errors []ValidationError
+}
+
+func (v *validator) addError(pointer string, format string, args ...interface{}) {
+	v.errors = append(v.errors, ValidationError{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) resolve(schema *jsonSchema) *jsonSchema {
+	for schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/definitions/")
+		schema = v.root.Definitions[name]
+	}
+	return schema
+}
+
+func (v *validator) validate(pointer string, value interface{}, schema *jsonSchema) {
+	schema = v.resolve(schema)
+
+	if len(schema.Type) > 0 && !matchesType(value, schema.Type) {
+		v.addError(pointer, "expected %s, got %s", strings.Join(schema.Type, " or "), jsonType(value))
+		return
+	}
+
+	if len(schema.Enum) > 0 {
+		found := false
+		for _, allowed := range schema.Enum {
+			if reflect.DeepEqual(value, allowed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			allowed := make([]string, 0, len(schema.Enum))
+			for _, value := range schema.Enum {
+				allowed = append(allowed, formatJSONValue(value))
+			}
+			v.addError(pointer, "%s is not one of %s", formatJSONValue(value), strings.Join(allowed, ", "))
+		}
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(pointer, value, schema)
+	case []interface{}:
+		if schema.Items != nil {
+			for i, item := range value {
+				v.validate(fmt.Sprintf("%s/%d", pointer, i), item, schema.Items)
+			}
+		}
+	case float64:
+		if schema.Minimum != nil && value < *schema.Minimum {
+			v.addError(pointer, "must be greater than or equal to %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && value > *schema.Maximum {
+			v.addError(pointer, "must be less than or equal to %v", *schema.Maximum)
+		}
+	case string:
+		if schema.MinLength != nil && len(value) < *schema.MinLength {
+			v.addError(pointer, "must not be empty")
+		}
+		if schema.Pattern != "" && !regexp.MustCompile(schema.Pattern).MatchString(value) {
+			v.addError(pointer, "%s doesn't match the pattern %s", formatJSONValue(value), schema.Pattern)
+		}
+	}
+}
+
+func (v *validator) validateObject(pointer string, object map[string]interface{}, schema *jsonSchema) {
+	for _, field := range schema.Required {
+		if _, ok := object[field]; !ok {
+			v.addError(pointer+"/"+escapePointerToken(field), "required field is missing")
+		}
+	}
+
+	for key, value := range object {
+		fieldPointer := pointer + "/" + escapePointerToken(key)
+		if fieldSchema, ok := schema.Properties[key]; ok {
+			v.validate(fieldPointer, value, fieldSchema)
+			continue
+		}
+		switch {
+		case schema.AdditionalProperties == nil || (schema.AdditionalProperties.allowed && schema.AdditionalProperties.schema == nil):
+		case !schema.AdditionalProperties.allowed:
+			v.addError(fieldPointer, "unknown field")
+		default:
+			v.validate(fieldPointer, value, schema.AdditionalProperties.schema)
+		}
+	}
+}
+
+func matchesType(value interface{}, types []string) bool {
+	for _, t := range types {
+		switch t {
+		case "integer":
+			if number, ok := value.(float64); ok && number == math.Trunc(number) {
+				return true
+			}
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		default:
+			if jsonType(value) == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// checkApp looks for mistakes in an app definition which can't be expressed in its schema.
+func checkApp(app map[string]interface{}) []ValidationError {
+	var errors []ValidationError
+
+	_, hasCmd := app["cmd"]
+	_, hasArgs := app["args"]
+	_, hasContainer := app["container"]
+	if hasCmd && hasArgs {
+		errors = append(errors, ValidationError{Pointer: "/args", Message: "cmd and args can't be used together"})
+	}
+	if !hasCmd && !hasArgs && !hasContainer {
+		errors = append(errors, ValidationError{Message: "one of cmd, args or container must be set"})
+	}
+
+	_, hasPorts := app["ports"]
+	_, hasPortDefinitions := app["portDefinitions"]
+	if hasPorts && hasPortDefinitions {
+		errors = append(errors, ValidationError{Pointer: "/ports", Message: "ports and portDefinitions can't be used together"})
+	}
+
+	hostNetworking := true
+	networks, _ := app["networks"].([]interface{})
+	for _, network := range networks {
+		if network, ok := network.(map[string]interface{}); ok && network["mode"] != nil && network["mode"] != "host" {
+			hostNetworking = false
+		}
+	}
+	if portDefinitions, _ := app["portDefinitions"].([]interface{}); len(portDefinitions) > 0 && !hostNetworking {
+		errors = append(errors, ValidationError{
+			Pointer: "/portDefinitions",
+			Message: "portDefinitions can only be used with host networking, use container.portMappings instead",
+		})
+	}
+	if container, ok := app["container"].(map[string]interface{}); ok {
+		if portMappings, _ := container["portMappings"].([]interface{}); len(portMappings) > 0 && hostNetworking {
+			errors = append(errors, ValidationError{
+				Pointer: "/container/portMappings",
+				Message: "portMappings require container or bridge networking, use portDefinitions instead",
+			})
+		}
+	}
+
+	healthChecks, _ := app["healthChecks"].([]interface{})
+	for i, healthCheck := range healthChecks {
+		healthCheck, ok := healthCheck.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		_, hasPort := healthCheck["port"]
+		_, hasPortIndex := healthCheck["portIndex"]
+		if hasPort && hasPortIndex {
+			errors = append(errors, ValidationError{
+				Pointer: fmt.Sprintf("/healthChecks/%d/portIndex", i),
+				Message: "port and portIndex can't be used together",
+			})
+		}
+	}
+	return errors
+}
+
+// checkPod looks for mistakes in a pod definition which can't be expressed in its schema.
+func checkPod(pod map[string]interface{}) []ValidationError {
+	var errors []ValidationError
+
+	names := make(map[string]bool)
+	containers, _ := pod["containers"].([]interface{})
+	if _, ok := pod["containers"]; ok && len(containers) == 0 {
+		errors = append(errors, ValidationError{Pointer: "/containers", Message: "a pod needs at least one container"})
+	}
+	for i, container := range containers {
+		container, ok := container.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, ok := container["name"].(string); ok {
+			if names[name] {
+				errors = append(errors, ValidationError{
+					Pointer: fmt.Sprintf("/containers/%d/name", i),
+					Message: fmt.Sprintf("duplicate container name '%s'", name),
+				})
+			}
+			names[name] = true
+		}
+
+		exec, _ := container["exec"].(map[string]interface{})
+		command, _ := exec["command"].(map[string]interface{})
+		_, hasShell := command["shell"]
+		_, hasArgv := command["argv"]
+		if hasShell && hasArgv {
+			errors = append(errors, ValidationError{
+				Pointer: fmt.Sprintf("/containers/%d/exec/command/argv", i),
+				Message: "shell and argv can't be used together",
+			})
+		}
+	}
+	return errors
+}
@@ -0,0 +1,115 @@
+package marathon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateApp(t *testing.T) {
+	testCases := []struct {
+		name       string
+		definition string
+		errors     []string
+	}{
+		{
+			name:       "valid app",
+			definition: `{"id":"/prod/web","cmd":"sleep 100","cpus":0.1,"mem":32,"instances":2,"env":{"FOO":"bar","SECRET":{"secret":"s"}},"constraints":[["hostname","UNIQUE"]]}`,
+		},
+		{
+			name:       "valid docker app",
+			definition: `{"id":"web","container":{"type":"DOCKER","docker":{"image":"nginx"},"portMappings":[{"containerPort":80}]},"networks":[{"mode":"container/bridge"}]}`,
+		},
+		{
+			name:       "valid app with check",
+			definition: `{"id":"web","cmd":"sleep 100","check":{"http":{"portIndex":0,"path":"/ping","scheme":"HTTP"},"intervalSeconds":10}}`,
+		},
+		{
+			name:       "missing id",
+			definition: `{"cmd":"sleep 100"}`,
+			errors:     []string{"/id: required field is missing"},
+		},
+		{
+			name:       "invalid id",
+			definition: `{"id":"/Prod/web_1","cmd":"sleep 100"}`,
+			errors:     []string{`/id: "/Prod/web_1" doesn't match the pattern`},
+		},
+		{
+			name:       "unknown fields",
+			definition: `{"id":"/web","cmd":"sleep 100","instance":2,"container":{"docker":{"image":"nginx","img":"x"}}}`,
+			errors:     []string{"/container/docker/img: unknown field", "/instance: unknown field"},
+		},
+		{
+			name:       "type errors",
+			definition: `{"id":"/web","cmd":"sleep 100","instances":"2","cpus":-1,"mem":1.5,"labels":{"a":1},"healthChecks":[{"protocol":"GRPC","portIndex":0.5}]}`,
+			errors: []string{
+				"/cpus: must be greater than or equal to 0",
+				`/healthChecks/0/portIndex: expected integer, got number`,
+				`/healthChecks/0/protocol: "GRPC" is not one of "HTTP", "HTTPS", "TCP", "COMMAND", "MESOS_HTTP", "MESOS_HTTPS", "MESOS_TCP"`,
+				"/instances: expected integer, got string",
+				"/labels/a: expected string, got number",
+			},
+		},
+		{
+			name:       "cmd and args",
+			definition: `{"id":"/web","cmd":"sleep 100","args":["sleep","100"]}`,
+			errors:     []string{"/args: cmd and args can't be used together"},
+		},
+		{
+			name:       "nothing to run",
+			definition: `{"id":"/web"}`,
+			errors:     []string{"/: one of cmd, args or container must be set"},
+		},
+		{
+			name:       "ports conflicts",
+			definition: `{"id":"/web","cmd":"sleep 100","ports":[0],"portDefinitions":[{"port":0}],"networks":[{"mode":"container","name":"dcos"}]}`,
+			errors: []string{
+				"/portDefinitions: portDefinitions can only be used with host networking, use container.portMappings instead",
+				"/ports: ports and portDefinitions can't be used together",
+			},
+		},
+		{
+			name:       "port mappings with host networking",
+			definition: `{"id":"/web","container":{"docker":{"image":"nginx"},"portMappings":[{"containerPort":80}]}}`,
+			errors:     []string{"/container/portMappings: portMappings require container or bridge networking, use portDefinitions instead"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errors := ValidateApp(decodeJSON(t, tc.definition))
+			assert.Len(t, errors, len(tc.errors), "%v", errors)
+			for i := range tc.errors {
+				if i < len(errors) {
+					assert.Contains(t, errors[i].String(), tc.errors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidatePod(t *testing.T) {
+	valid := `{"id":"/db","scaling":{"kind":"fixed","instances":1},"containers":[
+		{"name":"server","resources":{"cpus":0.1,"mem":32},"exec":{"command":{"shell":"sleep 100"}},"image":{"kind":"DOCKER","id":"postgres"}}
+	]}`
+	assert.Empty(t, ValidatePod(decodeJSON(t, valid)))
+
+	invalid := `{"id":"/db","containers":[
+		{"name":"server","resources":{"cpus":"1"},"exec":{"command":{"shell":"sleep 100","argv":["sleep"]}}},
+		{"name":"server","resources":{}},
+		{"resources":{},"cmd":"sleep"}
+	]}`
+	var messages []string
+	for _, err := range ValidatePod(decodeJSON(t, invalid)) {
+		messages = append(messages, err.String())
+	}
+	assert.Equal(t, []string{
+		"/containers/0/exec/command/argv: shell and argv can't be used together",
+		"/containers/0/resources/cpus: expected number, got string",
+		"/containers/1/name: duplicate container name 'server'",
+		"/containers/2/cmd: unknown field",
+		"/containers/2/name: required field is missing",
+	}, messages)
+
+	assert.Equal(t, []ValidationError{{Pointer: "/containers", Message: "required field is missing"}}, ValidatePod(decodeJSON(t, `{"id":"/db"}`)))
+}
@@ -0,0 +1,151 @@
+package mesos
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	mesos "github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// Asciicast event types.
+const (
+	CastEventOutput = "o"
+	CastEventInput  = "i"
+	CastEventResize = "r"
+)
+
+// defaultCastWidth and defaultCastHeight are the terminal size of recordings without a TTY.
+const (
+	defaultCastWidth  = 80
+	defaultCastHeight = 24
+)
+
+// CastHeader is the first line of an asciicast v2 file.
+type CastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder records the I/O streams of a TaskIO session to an asciicast v2 file.
+// See https://github.com/asciinema/asciinema/blob/develop/doc/asciicast-v2.md.
+//
+// The methods of a nil Recorder are no-ops.
+type Recorder struct {
+	mu      sync.Mutex
+	w       io.Writer
+	start   time.Time
+	started bool
+	err     error
+
+	// Incomplete UTF-8 sequences at the end of a stream chunk, by event type.
+	// Event data are JSON strings so multi-byte characters must not be split.
+	pending map[string][]byte
+}
+
+// NewRecorder creates a Recorder writing to w. The header is written
+// when the TaskIO session starts, once the terminal size is known.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w, pending: make(map[string][]byte)}
+}
+
+// Err returns the first error encountered while writing the recording.
+func (r *Recorder) Err() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// begin writes the header of the recording, it is a no-op if it has already been written.
+func (r *Recorder) begin(width int, height int, title string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.beginLocked(width, height, title)
+}
+
+func (r *Recorder) beginLocked(width int, height int, title string) {
+	if r.started {
+		return
+	}
+	r.started = true
+	r.start = time.Now()
+
+	header, err := json.Marshal(CastHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: r.start.Unix(),
+		Title:     title,
+		Env:       map[string]string{"TERM": defaultTermValue},
+	})
+	if err == nil {
+		_, err = fmt.Fprintf(r.w, "%s\n", header)
+	}
+	r.setErr(err)
+}
+
+// record writes an event with the given type and data.
+func (r *Recorder) record(eventType string, data []byte) {
+	if r == nil || len(data) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.beginLocked(defaultCastWidth, defaultCastHeight, "")
+
+	data = append(r.pending[eventType], data...)
+	complete := len(data)
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				complete = i
+			}
+			break
+		}
+	}
+	r.pending[eventType] = append([]byte(nil), data[complete:]...)
+	if complete > 0 {
+		r.writeEvent(eventType, string(data[:complete]))
+	}
+}
+
+// recordResize writes a resize event, terminals without a size are ignored.
+func (r *Recorder) recordResize(ttyInfo *mesos.TTYInfo) {
+	columns, rows := ttyInfo.GetWindowSize().GetColumns(), ttyInfo.GetWindowSize().GetRows()
+	if r == nil || columns == 0 || rows == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.beginLocked(int(columns), int(rows), "")
+	r.writeEvent(CastEventResize, fmt.Sprintf("%dx%d", columns, rows))
+}
+
+func (r *Recorder) writeEvent(eventType string, data string) {
+	event, err := json.Marshal([]interface{}{eventType, data})
+	if err == nil {
+		elapsed := time.Since(r.start).Seconds()
+		// Insert the timestamp as a fixed-point number, as done by asciinema.
+		_, err = fmt.Fprintf(r.w, "[%.6f, %s\n", elapsed, event[1:])
+	}
+	r.setErr(err)
+}
+
+func (r *Recorder) setErr(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
@@ -0,0 +1,56 @@
+package mesos
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	mesos "github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	var out bytes.Buffer
+	recorder := NewRecorder(&out)
+
+	recorder.begin(120, 40, "bash")
+	recorder.record(CastEventInput, []byte("ls\r"))
+	recorder.record(CastEventOutput, []byte("caf\xc3"))
+	recorder.record(CastEventOutput, []byte("\xa9\r\n"))
+	recorder.recordResize(&mesos.TTYInfo{WindowSize: &mesos.TTYInfo_WindowSize{}})
+	recorder.recordResize(&mesos.TTYInfo{WindowSize: &mesos.TTYInfo_WindowSize{Columns: 100, Rows: 30}})
+	require.NoError(t, recorder.Err())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 5)
+
+	var header CastHeader
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &header))
+	assert.Equal(t, 2, header.Version)
+	assert.Equal(t, 120, header.Width)
+	assert.Equal(t, 40, header.Height)
+	assert.Equal(t, "bash", header.Title)
+
+	expected := [][]interface{}{
+		{"i", "ls\r"},
+		{"o", "caf"},
+		{"o", "é\r\n"},
+		{"r", "100x30"},
+	}
+	for i, line := range lines[1:] {
+		var event []interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &event), line)
+		require.Len(t, event, 3)
+		assert.IsType(t, float64(0), event[0])
+		assert.Equal(t, expected[i], event[1:])
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var recorder *Recorder
+	recorder.begin(80, 24, "")
+	recorder.record(CastEventOutput, []byte("hello"))
+	assert.NoError(t, recorder.Err())
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/dcos/dcos-cli/api"
@@ -105,6 +107,52 @@ func (c *Client) Download(agent string, filePath string) ([]byte, error) {
 	}
 }
 
+// DownloadRange streams a file in the sandbox of a task starting at offset.
+// It returns the body of the response along with the offset it actually starts
+// at, which is 0 when the agent doesn't honor the range. The body must be closed.
+func (c *Client) DownloadRange(agent string, filePath string, offset int64) (io.ReadCloser, int64, error) {
+	opts := []httpclient.Option{httpclient.Timeout(0)}
+	if offset > 0 {
+		opts = append(opts, httpclient.Header("Range", fmt.Sprintf("bytes=%d-", offset)))
+	}
+
+	resp, err := c.http.Get("/agent/"+agent+"/files/download?path="+url.QueryEscape(filePath), opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	switch resp.StatusCode {
+	case 200:
+		return resp.Body, 0, nil
+	case 206:
+		return resp.Body, offset, nil
+	default:
+		defer resp.Body.Close()
+		return nil, 0, httpResponseToError(resp)
+	}
+}
+
+// ReadFile reads at most length bytes at offset from a file in the sandbox of a task.
+// An offset of -1 returns no data and the size of the file as offset.
+func (c *Client) ReadFile(agent string, filePath string, offset int64, length int64) (*FileData, error) {
+	resp, err := c.http.Get(fmt.Sprintf("/agent/%s/files/read?path=%s&offset=%d&length=%d",
+		agent, url.QueryEscape(filePath), offset, length))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case 200:
+		var data FileData
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		if err != nil {
+			return nil, err
+		}
+		return &data, nil
+	default:
+		return nil, httpResponseToError(resp)
+	}
+}
+
 // Frameworks returns the frameworks of the connected cluster.
 func (c *Client) Frameworks() ([]master.Response_GetFrameworks_Framework, error) {
 	body := master.Call{
@@ -2,6 +2,7 @@ package mesos
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -212,3 +213,52 @@ func TestTeardownFramework(t *testing.T) {
 	err := c.TeardownFramework(expectedFrameworkID)
 	require.NoError(t, err)
 }
+
+func TestDownloadRange(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/agent/a1/files/download", r.URL.Path)
+		assert.Equal(t, "/sandbox/my file", r.URL.Query().Get("path"))
+		if r.Header.Get("Range") == "bytes=6-" {
+			w.WriteHeader(206)
+			w.Write([]byte("world"))
+			return
+		}
+		w.Write([]byte("hello world"))
+	}))
+	defer ts.Close()
+
+	c := NewClient(pluginutil.HTTPClient(ts.URL))
+
+	body, offset, err := c.DownloadRange("a1", "/sandbox/my file", 6)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+	body.Close()
+	assert.Equal(t, int64(6), offset)
+	assert.Equal(t, "world", string(data))
+
+	body, offset, err = c.DownloadRange("a1", "/sandbox/my file", 0)
+	require.NoError(t, err)
+	data, err = ioutil.ReadAll(body)
+	require.NoError(t, err)
+	body.Close()
+	assert.Equal(t, int64(0), offset)
+	assert.Equal(t, "hello world", string(data))
+}
+
+func TestReadFile(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/agent/a1/files/read", r.URL.Path)
+		assert.Equal(t, "/sandbox/logs/app.log", r.URL.Query().Get("path"))
+		assert.Equal(t, "6", r.URL.Query().Get("offset"))
+		assert.Equal(t, "5", r.URL.Query().Get("length"))
+		w.Write([]byte(`{"data":"world","offset":6}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(pluginutil.HTTPClient(ts.URL))
+
+	data, err := c.ReadFile("a1", "/sandbox/logs/app.log", 6, 5)
+	require.NoError(t, err)
+	assert.Equal(t, &FileData{Data: "world", Offset: 6}, data)
+}
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -39,6 +40,7 @@ type TaskIOOpts struct {
 	EscapeSequence    []byte
 	Sender            agentcalls.Sender
 	Logger            *logrus.Logger
+	Recorder          *Recorder
 }
 
 // TaskIO is an abstraction used to stream I/O between a running Mesos task and the local terminal.
@@ -110,6 +112,8 @@ func (t *TaskIO) Exec(cmd string, args ...string) (int, error) {
 	defer t.cancelFunc()
 	defer close(t.errCh)
 
+	t.startRecording(strings.Join(append([]string{cmd}, args...), " "))
+
 	// Launch nested container session.
 	t.wg.Add(1)
 	go func() {
@@ -136,6 +140,8 @@ func (t *TaskIO) Attach() (int, error) {
 	defer t.cancelFunc()
 	defer close(t.errCh)
 
+	t.startRecording("")
+
 	// Attach container outputs to the CLI stdout/stderr.
 	t.wg.Add(1)
 	go func() {
@@ -299,7 +305,11 @@ func (t *TaskIO) attachContainerInput() error {
 			defer func() {
 				// Push an empty string to indicate EOF to the server and close
 				// the input channel to signal that we are done processing input.
-				input <- []byte("")
+				// The session might already be over, in which case nobody reads it.
+				select {
+				case input <- []byte(""):
+				case <-t.ctx.Done():
+				}
 				close(input)
 			}()
 
@@ -309,6 +319,7 @@ func (t *TaskIO) attachContainerInput() error {
 				buf := make([]byte, 512)
 				n, err := t.opts.Stdin.Read(buf)
 				if n > 0 {
+					t.opts.Recorder.record(CastEventInput, buf[:n])
 					select {
 					case input <- buf[:n]:
 					case <-t.ctx.Done():
@@ -338,6 +349,7 @@ func (t *TaskIO) attachContainerInput() error {
 				return
 
 			case ttyInfo := <-ttyInfoCh:
+				t.opts.Recorder.recordResize(ttyInfo)
 				c := agentcalls.AttachContainerInputTTY(ttyInfo)
 
 				select {
@@ -495,6 +507,23 @@ func (t *TaskIO) waitContainer() (int, error) {
 	return exitStatus >> 8, nil
 }
 
+// startRecording starts the recording of the session, if any, with the size of the local terminal.
+func (t *TaskIO) startRecording(title string) {
+	if t.opts.Recorder == nil {
+		return
+	}
+
+	width, height := defaultCastWidth, defaultCastHeight
+	if t.opts.TTY {
+		if fd, err := t.stdinFd(); err == nil && terminal.IsTerminal(fd) {
+			if ttyInfo, err := t.ttyInfo(fd); err == nil {
+				width, height = int(ttyInfo.WindowSize.Columns), int(ttyInfo.WindowSize.Rows)
+			}
+		}
+	}
+	t.opts.Recorder.begin(width, height, title)
+}
+
 // stdinFd returns the file descriptor of stdin.
 func (t *TaskIO) stdinFd() (int, error) {
 	stdin, ok := t.opts.Stdin.(*os.File)
@@ -543,10 +572,12 @@ func (t *TaskIO) forwardContainerOutput(resp encoding.Decoder) error {
 			data := pio.GetData()
 			switch data.GetType() {
 			case agent.ProcessIO_Data_STDOUT:
+				t.opts.Recorder.record(CastEventOutput, data.GetData())
 				if err := forward(data.GetData(), t.opts.Stdout); err != nil {
 					return err
 				}
 			case agent.ProcessIO_Data_STDERR:
+				t.opts.Recorder.record(CastEventOutput, data.GetData())
 				if err := forward(data.GetData(), t.opts.Stderr); err != nil {
 					return err
 				}
@@ -213,6 +213,12 @@ type File struct {
 	UID   string  `json:"uid"`
 }
 
+// FileData is a chunk of a file read through the Mesos files API.
+type FileData struct {
+	Data   string `json:"data"`
+	Offset int64  `json:"offset"`
+}
+
 // Container represents one way a Mesos task can be ran
 type Container struct {
 	Type   string `json:"type"`